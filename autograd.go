@@ -0,0 +1,145 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// libtorch keeps the grad mode in a thread-local variable, whereas Go
+// schedules a goroutine onto any OS thread.  So SetGradEnabled, NoGrad and
+// NoGradGuard lock the calling goroutine to its current OS thread while
+// gradient computation is disabled.  No other goroutine runs on a locked
+// thread, and Go terminates the thread if the goroutine exits without
+// unlocking it, so the grad mode is per goroutine: disabling gradient
+// computation in a goroutine doesn't affect others.
+
+// IsGradEnabled returns if libtorch records operations of the calling
+// goroutine for autograd.
+func IsGradEnabled() bool {
+	return C.IsGradEnabled() != 0
+}
+
+// SetGradEnabled enables or disables autograd for the calling goroutine.
+// Disabling locks the goroutine to its OS thread until enabling again.
+func SetGradEnabled(enabled bool) {
+	setGradMode(enabled)
+}
+
+// setGradMode sets the grad mode of the calling goroutine.  SetGradEnabled
+// and NoGradGuard change the grad mode only through setGradMode, which holds
+// exactly one lock of the OS thread while gradient computation is disabled,
+// however they nest.  For example, after
+//
+//	SetGradEnabled(false)
+//	NoGrad(func() { SetGradEnabled(true) })
+//
+// the guard restores the disabled mode and locks the thread again.
+func setGradMode(enabled bool) {
+	if enabled == IsGradEnabled() {
+		return
+	}
+	if !enabled {
+		runtime.LockOSThread()
+	}
+	C.SetGradEnabled(boolToInt8(enabled))
+	if enabled {
+		runtime.UnlockOSThread()
+	}
+}
+
+// NoGradGuard disables gradient computation until Close, like
+// torch.no_grad() in Python.
+//
+//	g := torch.NewNoGradGuard()
+//	defer g.Close()
+type NoGradGuard struct {
+	prev   bool
+	closed bool
+}
+
+// NewNoGradGuard disables gradient computation, which locks the goroutine to
+// the current OS thread.
+func NewNoGradGuard() *NoGradGuard {
+	g := &NoGradGuard{prev: IsGradEnabled()}
+	setGradMode(false)
+	return g
+}
+
+// Close restores the previous grad mode, which unlocks the OS thread if the
+// mode is enabled.  It is safe to call Close more than once.
+func (g *NoGradGuard) Close() {
+	if g.closed {
+		return
+	}
+	g.closed = true
+	setGradMode(g.prev)
+}
+
+// NoGrad calls f with gradient computation disabled.  Operations in f don't
+// build the autograd graph, which saves memory in evaluation and inference.
+func NoGrad(f func()) {
+	g := NewNoGradGuard()
+	defer g.Close()
+	f()
+}
+
+// RequiresGrad returns if autograd records operations on a.
+func (a Tensor) RequiresGrad() bool {
+	var r C.int8_t
+	MustNil(unsafe.Pointer(C.Tensor_RequiresGrad(C.Tensor(*a.T), &r)))
+	return r != 0
+}
+
+// SetRequiresGrad sets if autograd should record operations on a.  Only leaf
+// tensors of floating point dtypes could require gradients.
+func (a Tensor) SetRequiresGrad(requiresGrad bool) {
+	MustNil(unsafe.Pointer(C.Tensor_SetRequiresGrad(C.Tensor(*a.T),
+		boolToInt8(requiresGrad))))
+}
+
+// IsLeaf returns if a is a leaf of the autograd graph, i.e., a tensor that
+// doesn't require gradients or that the user created directly.
+func (a Tensor) IsLeaf() bool {
+	var r C.int8_t
+	MustNil(unsafe.Pointer(C.Tensor_IsLeaf(C.Tensor(*a.T), &r)))
+	return r != 0
+}
+
+// GradFn returns the name of the autograd function that created a, e.g.,
+// "AddBackward0", or an empty string if a is a leaf.
+func (a Tensor) GradFn() string {
+	var name *C.char
+	MustNil(unsafe.Pointer(C.Tensor_GradFnName(C.Tensor(*a.T), &name)))
+	if name == nil {
+		return ""
+	}
+	defer C.FreeString(name)
+	return C.GoString(name)
+}
+
+// BackwardWith computes the gradient of a with respect to the leaves of the
+// autograd graph and accumulates it into their Grad.  grad is the gradient
+// of the final output with respect to a; it could be Tensor{} if a is a
+// scalar.  Unless retainGraph, BackwardWith frees the graph.  If createGraph,
+// BackwardWith records the backward pass so to compute higher order
+// derivatives.
+func (a Tensor) BackwardWith(grad Tensor, retainGraph, createGraph bool) {
+	var g C.Tensor
+	if grad.T != nil {
+		g = C.Tensor(*grad.T)
+	}
+	MustNil(unsafe.Pointer(C.Tensor_BackwardWith(C.Tensor(*a.T), g,
+		boolToInt8(retainGraph), boolToInt8(createGraph))))
+}
+
+// ZeroGrad sets the gradient of a, if defined, to zeros and detaches it from
+// any autograd graph.
+func (a Tensor) ZeroGrad() {
+	MustNil(unsafe.Pointer(C.Tensor_ZeroGrad(C.Tensor(*a.T))))
+}
@@ -0,0 +1,74 @@
+// Package autograd wraps the functional interface of libtorch autograd,
+// which computes gradients without accumulating them into Tensor.Grad.
+package autograd
+
+// #cgo CFLAGS: -I ${SRCDIR}/..
+// #cgo LDFLAGS: -L ${SRCDIR}/../cgotorch -Wl,-rpath ${SRCDIR}/../cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/../cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/../cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"unsafe"
+
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func boolToInt8(b bool) C.int8_t {
+	return C.int8_t(torch.BoolToInt8(b))
+}
+
+// cTensors returns the pointer to the first C tensor of torch.CTensors(ts).
+func cTensors(ts []torch.Tensor) *C.Tensor {
+	return (*C.Tensor)(unsafe.Pointer(&torch.CTensors(ts)[0]))
+}
+
+// Grad torch.autograd.grad returns the gradients of outputs with respect to
+// inputs.  gradOutputs could be nil if all outputs are scalars; otherwise, it
+// has the same length as outputs, and its elements are the gradients with
+// respect to the corresponding outputs, or Tensor{} for scalar outputs.  If
+// createGraph, Grad records the backward pass so the returned gradients
+// support autograd, as in the gradient penalty of WGAN-GP.  Grad panics if an
+// input doesn't contribute to outputs.
+func Grad(outputs, inputs, gradOutputs []torch.Tensor, createGraph bool) []torch.Tensor {
+	r, e := TryGrad(outputs, inputs, gradOutputs, createGraph)
+	if e != nil {
+		panic(e)
+	}
+	return r
+}
+
+// TryGrad is Grad that returns an error rather than panics
+func TryGrad(outputs, inputs, gradOutputs []torch.Tensor, createGraph bool) ([]torch.Tensor, error) {
+	return grad(outputs, inputs, gradOutputs, createGraph, createGraph, false)
+}
+
+// grad is torch.autograd.grad with all options.  If allowUnused, the
+// gradients with respect to inputs unused by outputs are Tensor{}.
+func grad(outputs, inputs, gradOutputs []torch.Tensor,
+	retainGraph, createGraph, allowUnused bool) ([]torch.Tensor, error) {
+	if gradOutputs == nil {
+		gradOutputs = make([]torch.Tensor, len(outputs))
+	}
+	if len(gradOutputs) != len(outputs) {
+		return nil, &torch.TorchError{Op: "Grad",
+			Message: "gradOutputs and outputs have different lengths"}
+	}
+	o, i, g := cTensors(outputs), cTensors(inputs), cTensors(gradOutputs)
+	r := make([]C.Tensor, len(inputs)+1)
+	err := C.Autograd_Grad(o, C.int64_t(len(outputs)),
+		i, C.int64_t(len(inputs)), g, boolToInt8(retainGraph),
+		boolToInt8(createGraph), boolToInt8(allowUnused), &r[0])
+	if e := torch.CheckNil("Grad", unsafe.Pointer(err), outputs...); e != nil {
+		return nil, e
+	}
+	grads := make([]torch.Tensor, len(inputs))
+	for k := range grads {
+		if r[k] == nil {
+			continue
+		}
+		torch.SetTensorFinalizer((*unsafe.Pointer)(&r[k]))
+		grads[k] = torch.Tensor{T: (*unsafe.Pointer)(&r[k])}
+	}
+	return grads, nil
+}
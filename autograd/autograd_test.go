@@ -0,0 +1,47 @@
+package autograd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+	"github.com/wangkuiyi/gotorch/autograd"
+)
+
+// >>> x = torch.tensor([1., 2., 3.], requires_grad=True)
+// >>> y = (x * x).sum()
+// >>> g, = torch.autograd.grad(y, x, create_graph=True)
+// >>> g
+// tensor([2., 4., 6.], grad_fn=<AddBackward0>)
+// >>> torch.autograd.grad(g.sum(), x)
+// (tensor([2., 2., 2.]),)
+func TestGrad(t *testing.T) {
+	x := torch.NewTensor([]float32{1, 2, 3}, map[string]interface{}{"requires_grad": true})
+	y := torch.Mul(x, x).Sum()
+	g := autograd.Grad([]torch.Tensor{y}, []torch.Tensor{x}, nil, true)
+	assert.Equal(t, 1, len(g))
+	assert.Equal(t, []float32{2, 4, 6}, g[0].Float32s())
+	assert.True(t, g[0].RequiresGrad())
+	assert.False(t, x.Grad().Defined())
+
+	gg := autograd.Grad([]torch.Tensor{g[0].Sum()}, []torch.Tensor{x}, nil, false)
+	assert.Equal(t, []float32{2, 2, 2}, gg[0].Float32s())
+	assert.False(t, x.Grad().Defined())
+}
+
+func TestGradWithGradOutputs(t *testing.T) {
+	x := torch.NewTensor([]float32{1, 2}, map[string]interface{}{"requires_grad": true})
+	y := torch.Mul(x, x)
+	g := autograd.Grad([]torch.Tensor{y}, []torch.Tensor{x},
+		[]torch.Tensor{torch.NewTensor([]float32{1, 10})}, false)
+	assert.Equal(t, []float32{2, 40}, g[0].Float32s())
+}
+
+func TestTryGrad(t *testing.T) {
+	x := torch.NewTensor([]float32{1, 2}, map[string]interface{}{"requires_grad": true})
+	y := torch.Mul(x, x)
+	_, e := autograd.TryGrad([]torch.Tensor{y}, []torch.Tensor{x}, nil, false)
+	assert.Error(t, e) // y is not a scalar
+	_, e = autograd.TryGrad([]torch.Tensor{y}, []torch.Tensor{x}, []torch.Tensor{}, false)
+	assert.Error(t, e)
+}
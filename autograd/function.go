@@ -0,0 +1,190 @@
+package autograd
+
+// #cgo CFLAGS: -I ${SRCDIR}/..
+// #cgo LDFLAGS: -L ${SRCDIR}/../cgotorch -Wl,-rpath ${SRCDIR}/../cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/../cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/../cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include <stdlib.h>
+// #include "cgotorch/cgotorch.h"
+//
+// extern char *goAutogradForward(int64_t handle, Tensor *inputs,
+//                                int64_t inputs_len, Tensor **outputs,
+//                                int64_t *outputs_len);
+// extern char *goAutogradBackward(int64_t handle, Tensor *inputs,
+//                                 int64_t inputs_len, Tensor **outputs,
+//                                 int64_t *outputs_len);
+// extern void goAutogradRelease(int64_t handle, int8_t all);
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+// Function is an operation with Go implementations of the forward and the
+// backward passes, like a subclass of torch.autograd.Function in Python.
+// Apply calls Forward with gradient computation disabled, and the backward
+// pass of autograd calls Backward.
+type Function interface {
+	// Forward computes the outputs from inputs.  It could save tensors in
+	// ctx for Backward.
+	Forward(ctx *Context, inputs []torch.Tensor) []torch.Tensor
+	// Backward returns the gradients with respect to the inputs of
+	// Forward given those with respect to the outputs.  It must return
+	// a gradient for each input, or Tensor{} for inputs that don't need
+	// gradients.
+	Backward(ctx *Context, gradOutputs []torch.Tensor) []torch.Tensor
+}
+
+// Context passes information from Forward to Backward of a call to Apply.
+type Context struct {
+	saved []torch.Tensor
+	// Values keeps non-tensor information, e.g., the shapes of inputs.
+	Values map[string]interface{}
+}
+
+// SaveForBackward keeps tensors for Backward.  Saving an output of Forward
+// makes a reference cycle, which keeps the autograd graph in memory; save
+// the inputs whenever possible.
+func (ctx *Context) SaveForBackward(tensors ...torch.Tensor) {
+	ctx.saved = append(ctx.saved, tensors...)
+}
+
+// SavedTensors returns the tensors saved by SaveForBackward.
+func (ctx *Context) SavedTensors() []torch.Tensor {
+	return ctx.saved
+}
+
+// call is a pending call to Apply, which lives until libtorch frees the
+// autograd graph node.
+type call struct {
+	f   Function
+	ctx *Context
+}
+
+var (
+	callsMu    sync.Mutex
+	calls      = make(map[int64]*call)
+	nextHandle int64
+)
+
+func init() {
+	C.Autograd_SetCallbacks((C.AutogradCallback)(C.goAutogradForward),
+		(C.AutogradCallback)(C.goAutogradBackward),
+		(C.AutogradReleaseCallback)(C.goAutogradRelease))
+}
+
+func lookupCall(handle C.int64_t) *call {
+	callsMu.Lock()
+	defer callsMu.Unlock()
+	return calls[int64(handle)]
+}
+
+// Apply calls f.Forward on inputs and records the call in the autograd graph,
+// so Tensor.Backward and Grad run f.Backward.
+func Apply(f Function, inputs ...torch.Tensor) []torch.Tensor {
+	r, e := TryApply(f, inputs...)
+	if e != nil {
+		panic(e)
+	}
+	return r
+}
+
+// TryApply is Apply that returns an error rather than panics.  A panic in
+// f.Forward becomes the error.
+func TryApply(f Function, inputs ...torch.Tensor) ([]torch.Tensor, error) {
+	callsMu.Lock()
+	nextHandle++
+	handle := nextHandle
+	calls[handle] = &call{f: f, ctx: &Context{Values: make(map[string]interface{})}}
+	callsMu.Unlock()
+
+	in := cTensors(inputs)
+	var out *C.Tensor
+	var n C.int64_t
+	err := C.Autograd_Apply(C.int64_t(handle), in, C.int64_t(len(inputs)), &out, &n)
+	if e := torch.CheckNil("Apply", unsafe.Pointer(err), inputs...); e != nil {
+		return nil, e
+	}
+	defer C.free(unsafe.Pointer(out))
+	return wrapTensors(out, n), nil
+}
+
+// wrapTensors takes the ownership of n C tensors at p.  Elements of nil
+// become Tensor{}.
+func wrapTensors(p *C.Tensor, n C.int64_t) []torch.Tensor {
+	r := make([]torch.Tensor, n)
+	if n == 0 {
+		return r
+	}
+	ts := make([]C.Tensor, n)
+	copy(ts, (*[1 << 30]C.Tensor)(unsafe.Pointer(p))[:n:n])
+	for i := range ts {
+		if ts[i] != nil {
+			torch.SetTensorFinalizer((*unsafe.Pointer)(&ts[i]))
+			r[i] = torch.Tensor{T: (*unsafe.Pointer)(&ts[i])}
+		}
+	}
+	return r
+}
+
+// newRefs returns a C array, allocated by malloc, of new references to ts,
+// so they outlive ts in case Go collects ts.
+func newRefs(ts []torch.Tensor) (*C.Tensor, C.int64_t) {
+	if len(ts) == 0 {
+		return nil, 0
+	}
+	p := (*C.Tensor)(C.malloc(C.size_t(len(ts)) * C.size_t(unsafe.Sizeof(C.Tensor(nil)))))
+	a := (*[1 << 30]C.Tensor)(unsafe.Pointer(p))[:len(ts):len(ts)]
+	for i, t := range ts {
+		a[i] = nil
+		if t.Defined() {
+			a[i] = C.Autograd_NewRef(C.Tensor(*t.T))
+		}
+	}
+	return p, C.int64_t(len(ts))
+}
+
+func callGo(handle C.int64_t, inputs *C.Tensor, n C.int64_t,
+	outputs **C.Tensor, outputsLen *C.int64_t, forward bool) (err *C.char) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = C.CString(fmt.Sprint(r))
+		}
+	}()
+	in := wrapTensors(inputs, n)
+	c := lookupCall(handle)
+	var out []torch.Tensor
+	if forward {
+		out = c.f.Forward(c.ctx, in)
+	} else {
+		out = c.f.Backward(c.ctx, in)
+	}
+	*outputs, *outputsLen = newRefs(out)
+	return nil
+}
+
+//export goAutogradForward
+func goAutogradForward(handle C.int64_t, inputs *C.Tensor, n C.int64_t,
+	outputs **C.Tensor, outputsLen *C.int64_t) *C.char {
+	return callGo(handle, inputs, n, outputs, outputsLen, true)
+}
+
+//export goAutogradBackward
+func goAutogradBackward(handle C.int64_t, inputs *C.Tensor, n C.int64_t,
+	outputs **C.Tensor, outputsLen *C.int64_t) *C.char {
+	return callGo(handle, inputs, n, outputs, outputsLen, false)
+}
+
+//export goAutogradRelease
+func goAutogradRelease(handle C.int64_t, all C.int8_t) {
+	callsMu.Lock()
+	defer callsMu.Unlock()
+	if all != 0 {
+		delete(calls, int64(handle))
+	} else if c, ok := calls[int64(handle)]; ok {
+		c.ctx.saved = nil
+	}
+}
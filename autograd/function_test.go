@@ -0,0 +1,70 @@
+package autograd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+	"github.com/wangkuiyi/gotorch/autograd"
+)
+
+// square computes x*x and scales the gradient by Values["scale"] to make
+// sure Backward, not libtorch, computes the gradient.
+type square struct{ scale float64 }
+
+func (f *square) Forward(ctx *autograd.Context, inputs []torch.Tensor) []torch.Tensor {
+	ctx.SaveForBackward(inputs[0])
+	ctx.Values["scale"] = f.scale
+	return []torch.Tensor{torch.Mul(inputs[0], inputs[0])}
+}
+
+func (f *square) Backward(ctx *autograd.Context, gradOutputs []torch.Tensor) []torch.Tensor {
+	x := ctx.SavedTensors()[0]
+	g := torch.Mul(x, gradOutputs[0]).MulScalar(2 * ctx.Values["scale"].(float64))
+	return []torch.Tensor{g}
+}
+
+func TestFunction(t *testing.T) {
+	x := torch.NewTensor([]float32{1, 2, 3}, map[string]interface{}{"requires_grad": true})
+	y := autograd.Apply(&square{scale: 10}, x)
+	assert.Equal(t, 1, len(y))
+	assert.Equal(t, []float32{1, 4, 9}, y[0].Float32s())
+	assert.True(t, y[0].RequiresGrad())
+	assert.Equal(t, "GoFunctionBackward", y[0].GradFn())
+
+	y[0].Sum().Backward()
+	assert.Equal(t, []float32{20, 40, 60}, x.Grad().Float32s())
+}
+
+func TestFunctionWithoutGrad(t *testing.T) {
+	x := torch.NewTensor([]float32{1, 2})
+	y := autograd.Apply(&square{scale: 1}, x)
+	assert.False(t, y[0].RequiresGrad())
+	assert.Equal(t, []float32{1, 4}, y[0].Float32s())
+}
+
+type faulty struct{ forward bool }
+
+func (f *faulty) Forward(ctx *autograd.Context, inputs []torch.Tensor) []torch.Tensor {
+	if f.forward {
+		panic("something wrong")
+	}
+	return []torch.Tensor{inputs[0].MulScalar(2)}
+}
+
+func (f *faulty) Backward(ctx *autograd.Context, gradOutputs []torch.Tensor) []torch.Tensor {
+	return nil
+}
+
+func TestFunctionError(t *testing.T) {
+	x := torch.NewTensor([]float32{1, 2}, map[string]interface{}{"requires_grad": true})
+	_, e := autograd.TryApply(&faulty{forward: true}, x)
+	assert.Error(t, e)
+	assert.Contains(t, e.Error(), "something wrong")
+
+	_, e = autograd.TryApply(&square{scale: 1}, x, torch.Tensor{})
+	assert.Contains(t, e.Error(), "input 1 is undefined")
+
+	y := autograd.Apply(&faulty{forward: false}, x)
+	assert.Panics(t, func() { y[0].Sum().BackwardWith(torch.Tensor{}, false, false) })
+}
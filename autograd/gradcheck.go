@@ -0,0 +1,179 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+// GradCheck compares the gradients of f with respect to inputs computed by
+// autograd with those computed by central finite differences with step eps,
+// like torch.autograd.gradcheck.  It checks the inputs that require
+// gradients.  GradCheck casts floating point inputs to torch.Double before
+// calling f, so f should work with double precision.  It returns nil if all
+// elements of the Jacobians satisfy
+//
+//	|analytical - numerical| <= atol + rtol * |numerical|
+//
+// or an error reporting the worst mismatching element otherwise.  A typical
+// use in tests is
+//
+//	assert.NoError(t, autograd.GradCheck(f, inputs, 1e-6, 1e-5, 1e-3))
+func GradCheck(f func([]torch.Tensor) torch.Tensor, inputs []torch.Tensor,
+	eps, atol, rtol float64) error {
+	return gradCheck("GradCheck", f, toDouble(inputs), eps, atol, rtol)
+}
+
+// GradGradCheck checks the second order gradients of f like
+// torch.autograd.gradgradcheck.  It runs GradCheck on the function that
+// computes the gradients of f with respect to inputs, given a fixed random
+// gradient with respect to the output of f.
+func GradGradCheck(f func([]torch.Tensor) torch.Tensor, inputs []torch.Tensor,
+	eps, atol, rtol float64) error {
+	xs := toDouble(inputs)
+	shape := f(xs).Shape()
+	rng := rand.New(rand.NewSource(1))
+	v := make([]float64, numel(shape))
+	for i := range v {
+		v[i] = rng.NormFloat64()
+	}
+	gradOutput := torch.NewTensor(v).Reshape(shape...)
+
+	df := func(xs []torch.Tensor) torch.Tensor {
+		diff := requiringGrad(xs)
+		ins := make([]torch.Tensor, len(diff))
+		for i, k := range diff {
+			ins[i] = xs[k]
+		}
+		grads, e := grad([]torch.Tensor{f(xs)}, ins,
+			[]torch.Tensor{gradOutput}, true, true, true)
+		if e != nil {
+			panic(e)
+		}
+		flat := make([]torch.Tensor, len(grads))
+		for i, g := range grads {
+			if !g.Defined() {
+				g = torch.NewTensor(make([]float64, numel(ins[i].Shape())))
+			}
+			flat[i] = g.Reshape(-1)
+		}
+		return torch.Cat(flat, 0)
+	}
+	return gradCheck("GradGradCheck", df, xs, eps, atol, rtol)
+}
+
+func gradCheck(name string, f func([]torch.Tensor) torch.Tensor,
+	xs []torch.Tensor, eps, atol, rtol float64) error {
+	diff := requiringGrad(xs)
+	if len(diff) == 0 {
+		return fmt.Errorf("%s: no input requires gradients", name)
+	}
+	ins := make([]torch.Tensor, len(diff))
+	for i, k := range diff {
+		ins[i] = xs[k]
+	}
+
+	// analytical[i][j] is the gradient of the j-th output element with
+	// respect to ins[i].
+	y := f(xs)
+	m := numel(y.Shape())
+	analytical := make([][][]float64, len(ins))
+	for i := range analytical {
+		analytical[i] = make([][]float64, m)
+	}
+	for j := 0; j < m; j++ {
+		onehot := make([]float64, m)
+		onehot[j] = 1
+		grads, e := grad([]torch.Tensor{y}, ins,
+			[]torch.Tensor{torch.NewTensor(onehot).Reshape(y.Shape()...)},
+			true, false, true)
+		if e != nil {
+			return fmt.Errorf("%s: %v", name, e)
+		}
+		for i, g := range grads {
+			if g.Defined() {
+				analytical[i][j] = g.Float64s()
+			} else {
+				analytical[i][j] = make([]float64, numel(ins[i].Shape()))
+			}
+		}
+	}
+
+	var worst struct {
+		excess              float64
+		input, elem, output int
+		analytical, numeric float64
+	}
+	for i, k := range diff {
+		x := xs[k].Float64s()
+		shape := xs[k].Shape()
+		eval := func(elem int, delta float64) []float64 {
+			v := append([]float64(nil), x...)
+			v[elem] += delta
+			p := torch.NewTensor(v).Reshape(shape...)
+			p.SetRequiresGrad(true)
+			perturbed := append([]torch.Tensor(nil), xs...)
+			perturbed[k] = p
+			return f(perturbed).Float64s()
+		}
+		for elem := range x {
+			plus, minus := eval(elem, eps), eval(elem, -eps)
+			for j := 0; j < m; j++ {
+				n := (plus[j] - minus[j]) / (2 * eps)
+				a := analytical[i][j][elem]
+				excess := math.Abs(a-n) - (atol + rtol*math.Abs(n))
+				if excess > worst.excess || math.IsNaN(excess) {
+					worst.excess = excess
+					worst.input, worst.elem, worst.output = k, elem, j
+					worst.analytical, worst.numeric = a, n
+				}
+			}
+		}
+	}
+	if worst.excess > 0 || math.IsNaN(worst.excess) {
+		return fmt.Errorf("%s: Jacobian mismatch of output element %d with "+
+			"respect to element %d of input %d: analytical %g, numerical %g",
+			name, worst.output, worst.elem, worst.input,
+			worst.analytical, worst.numeric)
+	}
+	return nil
+}
+
+// toDouble returns detached copies of inputs, with floating point ones cast
+// to torch.Double.  The copies require gradients if the inputs do.
+func toDouble(inputs []torch.Tensor) []torch.Tensor {
+	xs := make([]torch.Tensor, len(inputs))
+	for i := range inputs {
+		x := inputs[i].Detach()
+		switch x.Dtype() {
+		case torch.Half, torch.Float, torch.BFloat16:
+			x = x.CastTo(torch.Double)
+		}
+		if inputs[i].RequiresGrad() {
+			x.SetRequiresGrad(true)
+		}
+		xs[i] = x
+	}
+	return xs
+}
+
+// requiringGrad returns the indices of xs that require gradients.
+func requiringGrad(xs []torch.Tensor) []int {
+	var r []int
+	for i, x := range xs {
+		if x.RequiresGrad() {
+			r = append(r, i)
+		}
+	}
+	return r
+}
+
+func numel(shape []int64) int {
+	n := 1
+	for _, d := range shape {
+		n *= int(d)
+	}
+	return n
+}
@@ -0,0 +1,38 @@
+package autograd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+	"github.com/wangkuiyi/gotorch/autograd"
+)
+
+func TestGradCheck(t *testing.T) {
+	f := func(in []torch.Tensor) torch.Tensor {
+		return torch.Add(torch.Mul(in[0], in[0]), torch.Sin(in[1]), 1)
+	}
+	x := torch.RandN([]int64{2, 3}, true)
+	y := torch.RandN([]int64{2, 3}, true)
+	assert.NoError(t, autograd.GradCheck(f, []torch.Tensor{x, y}, 1e-6, 1e-5, 1e-3))
+	assert.NoError(t, autograd.GradGradCheck(f, []torch.Tensor{x, y}, 1e-6, 1e-5, 1e-3))
+
+	e := autograd.GradCheck(f, []torch.Tensor{x.Detach(), y.Detach()}, 1e-6, 1e-5, 1e-3)
+	assert.Error(t, e)
+}
+
+func TestGradCheckMismatch(t *testing.T) {
+	// square.Backward scales the gradient by 10.
+	f := func(in []torch.Tensor) torch.Tensor {
+		return autograd.Apply(&square{scale: 10}, in[0])[0]
+	}
+	x := torch.NewTensor([]float32{1, 2, 3}, map[string]interface{}{"requires_grad": true})
+	e := autograd.GradCheck(f, []torch.Tensor{x}, 1e-6, 1e-5, 1e-3)
+	assert.Error(t, e)
+	assert.Contains(t, e.Error(), "analytical 60,")
+
+	f = func(in []torch.Tensor) torch.Tensor {
+		return autograd.Apply(&square{scale: 1}, in[0])[0]
+	}
+	assert.NoError(t, autograd.GradCheck(f, []torch.Tensor{x}, 1e-6, 1e-5, 1e-3))
+}
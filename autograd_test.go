@@ -0,0 +1,71 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestNoGrad(t *testing.T) {
+	a := torch.RandN([]int64{2, 3}, true)
+	assert.True(t, torch.IsGradEnabled())
+	var b torch.Tensor
+	torch.NoGrad(func() {
+		assert.False(t, torch.IsGradEnabled())
+		b = torch.Add(a, a, 1)
+	})
+	assert.True(t, torch.IsGradEnabled())
+	assert.False(t, b.RequiresGrad())
+	assert.Equal(t, "", b.GradFn())
+
+	c := torch.Add(a, a, 1)
+	assert.True(t, c.RequiresGrad())
+	assert.Equal(t, "AddBackward0", c.GradFn())
+}
+
+func TestNoGradGuard(t *testing.T) {
+	g := torch.NewNoGradGuard()
+	assert.False(t, torch.IsGradEnabled())
+	g2 := torch.NewNoGradGuard()
+	g2.Close()
+	assert.False(t, torch.IsGradEnabled())
+	g.Close()
+	g.Close()
+	assert.True(t, torch.IsGradEnabled())
+}
+
+func TestSetRequiresGrad(t *testing.T) {
+	a := torch.RandN([]int64{2, 3}, false)
+	assert.False(t, a.RequiresGrad())
+	assert.True(t, a.IsLeaf())
+	a.SetRequiresGrad(true)
+	assert.True(t, a.RequiresGrad())
+
+	b := a.Mean()
+	assert.False(t, b.IsLeaf())
+	assert.Panics(t, func() { b.SetRequiresGrad(false) })
+
+	c := torch.NewTensor([]float32{1, 2}, map[string]interface{}{"requires_grad": true})
+	assert.True(t, c.RequiresGrad())
+}
+
+func TestBackwardWith(t *testing.T) {
+	x := torch.NewTensor([]float32{1, 2}, map[string]interface{}{"requires_grad": true})
+	assert.False(t, x.Grad().Defined())
+	y := torch.Mul(x, x)
+	y.BackwardWith(torch.NewTensor([]float32{1, 1}), true, false)
+	assert.True(t, x.Grad().Defined())
+	assert.Equal(t, []float32{2, 4}, x.Grad().Float32s())
+
+	// The graph is retained, so we could run backward again and accumulate.
+	y.BackwardWith(torch.NewTensor([]float32{1, 1}), false, false)
+	assert.Equal(t, []float32{4, 8}, x.Grad().Float32s())
+	assert.Panics(t, func() {
+		y.BackwardWith(torch.NewTensor([]float32{1, 1}), false, false)
+	})
+
+	x.ZeroGrad()
+	assert.Equal(t, []float32{0, 0}, x.Grad().Float32s())
+	assert.False(t, torch.Tensor{}.Defined())
+}
@@ -0,0 +1,65 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Helpers passing Go values to cgotorch functions.  Each package calling cgo
+// has its own C types, so the exported helpers, which packages like linalg,
+// autograd and nn/functional use, return Go types that callers convert to C
+// types, e.g., C.int8_t(torch.BoolToInt8(b)).
+
+// BoolToInt8 returns 1 for true and 0 for false, the booleans of cgotorch
+func BoolToInt8(b bool) int8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// DimsPtr returns the pointer to the first element of dims, or nil if dims is
+// empty, for cgotorch functions taking an int64_t array and its length.
+func DimsPtr(dims []int64) unsafe.Pointer {
+	if len(dims) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&dims[0])
+}
+
+// CTensors returns the C tensors wrapped by ts, with an extra nil element so
+// that taking the address of the first element is valid even if ts is empty.
+// Undefined elements of ts become nil.
+func CTensors(ts []Tensor) []unsafe.Pointer {
+	r := make([]unsafe.Pointer, len(ts)+1)
+	for i, t := range ts {
+		if t.T != nil {
+			r[i] = *t.T
+		}
+	}
+	return r
+}
+
+func boolToInt8(b bool) C.int8_t {
+	return C.int8_t(BoolToInt8(b))
+}
+
+func dimsPtr(dims []int64) *C.int64_t {
+	return (*C.int64_t)(DimsPtr(dims))
+}
+
+// cTensors is CTensors with the C type of this package.
+func cTensors(ts []Tensor) []C.Tensor {
+	r := make([]C.Tensor, len(ts)+1)
+	for i, t := range ts {
+		if t.T != nil {
+			r[i] = C.Tensor(*t.T)
+		}
+	}
+	return r
+}
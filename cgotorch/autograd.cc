@@ -0,0 +1,240 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/autograd.h"
+
+#include <torch/csrc/autograd/custom_function.h>
+
+#include <algorithm>
+#include <cstdlib>
+#include <memory>
+#include <stdexcept>
+#include <string>
+#include <vector>
+
+void SetGradEnabled(int8_t enabled) {
+  torch::autograd::GradMode::set_enabled(enabled != 0);
+}
+
+int8_t IsGradEnabled() {
+  return torch::autograd::GradMode::is_enabled() ? 1 : 0;
+}
+
+const char *Tensor_RequiresGrad(Tensor a, int8_t *result) {
+  try {
+    *result = a->requires_grad() ? 1 : 0;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_SetRequiresGrad(Tensor a, int8_t requires_grad) {
+  try {
+    a->set_requires_grad(requires_grad != 0);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_IsLeaf(Tensor a, int8_t *result) {
+  try {
+    *result = a->is_leaf() ? 1 : 0;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_GradFnName(Tensor a, const char **name) {
+  try {
+    auto fn = a->grad_fn();
+    if (fn == nullptr) {
+      *name = nullptr;
+      return nullptr;
+    }
+    std::string s = fn->name();
+    char *r = new char[s.size() + 1];
+    snprintf(r, s.size() + 1, "%s", s.c_str());
+    *name = r;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_BackwardWith(Tensor a, Tensor grad, int8_t retain_graph,
+                                int8_t create_graph) {
+  try {
+    a->backward(grad == nullptr ? at::Tensor() : *grad,
+                retain_graph != 0, create_graph != 0);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// Tensor_ZeroGrad follows torch::optim::Optimizer::zero_grad in libtorch 1.6.
+const char *Tensor_ZeroGrad(Tensor a) {
+  try {
+    auto &grad = a->mutable_grad();
+    if (grad.defined()) {
+      grad = grad.detach();
+      grad.zero_();
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Autograd_Grad(Tensor *outputs, int64_t outputs_len,
+                          Tensor *inputs, int64_t inputs_len,
+                          Tensor *grad_outputs, int8_t retain_graph,
+                          int8_t create_graph, int8_t allow_unused,
+                          Tensor *results) {
+  try {
+    std::vector<at::Tensor> o, i, g;
+    for (int64_t k = 0; k < outputs_len; ++k) {
+      o.push_back(*outputs[k]);
+      g.push_back(grad_outputs[k] == nullptr ? at::Tensor()
+                                             : *grad_outputs[k]);
+    }
+    for (int64_t k = 0; k < inputs_len; ++k) i.push_back(*inputs[k]);
+    auto r = torch::autograd::grad(o, i, g, retain_graph != 0,
+                                   create_graph != 0, allow_unused != 0);
+    for (int64_t k = 0; k < inputs_len; ++k) {
+      results[k] = r[k].defined() ? new at::Tensor(r[k]) : nullptr;
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+namespace {
+
+AutogradCallback go_forward = nullptr;
+AutogradCallback go_backward = nullptr;
+AutogradReleaseCallback go_release = nullptr;
+
+torch::autograd::variable_list CallGo(AutogradCallback callback,
+                                      int64_t handle,
+                                      const torch::autograd::variable_list &in) {
+  std::vector<Tensor> args;
+  for (auto &t : in) {
+    args.push_back(t.defined() ? new at::Tensor(t) : nullptr);
+  }
+  Tensor *out = nullptr;
+  int64_t out_len = 0;
+  char *err = callback(handle, args.data(), args.size(), &out, &out_len);
+  if (err != nullptr) {
+    std::string msg(err);
+    free(err);
+    throw std::runtime_error(msg);
+  }
+  torch::autograd::variable_list r;
+  for (int64_t i = 0; i < out_len; ++i) {
+    r.push_back(out[i] == nullptr ? at::Tensor() : *out[i]);
+    delete out[i];
+  }
+  free(out);
+  return r;
+}
+
+// GoFunctionNode is the node in the autograd graph for a Go function.  Its
+// lifetime decides that of the Go function and its context.
+struct GoFunctionNode : public torch::autograd::Node {
+  explicit GoFunctionNode(int64_t handle) : handle_(handle) {}
+  ~GoFunctionNode() override { go_release(handle_, 1); }
+
+  std::string name() const override { return "GoFunctionBackward"; }
+
+  torch::autograd::variable_list apply(
+      torch::autograd::variable_list &&grads) override {
+    // Like torch::autograd::CppNode, materialize undefined gradients.
+    for (size_t i = 0; i < grads.size(); ++i) {
+      if (!grads[i].defined()) {
+        grads[i] = at::zeros(sizes_[i], options_[i]);
+      }
+    }
+    auto r = CallGo(go_backward, handle_, grads);
+    if (r.size() != num_outputs()) {
+      throw std::runtime_error(
+          "GoFunction: Backward returned " + std::to_string(r.size()) +
+          " gradients, expecting " + std::to_string(num_outputs()));
+    }
+    return r;
+  }
+
+  void release_variables() override { go_release(handle_, 0); }
+
+  int64_t handle_;
+  std::vector<std::vector<int64_t>> sizes_;
+  std::vector<at::TensorOptions> options_;
+};
+
+}  // namespace
+
+void Autograd_SetCallbacks(AutogradCallback forward, AutogradCallback backward,
+                           AutogradReleaseCallback release) {
+  go_forward = forward;
+  go_backward = backward;
+  go_release = release;
+}
+
+const char *Autograd_Apply(int64_t handle, Tensor *inputs, int64_t inputs_len,
+                           Tensor **outputs, int64_t *outputs_len) {
+  try {
+    // The node releases the Go call when destroyed, including on errors.
+    std::shared_ptr<GoFunctionNode> node(new GoFunctionNode(handle),
+                                         torch::autograd::deleteNode);
+    torch::autograd::variable_list in;
+    for (int64_t i = 0; i < inputs_len; ++i) {
+      if (inputs[i] == nullptr || !inputs[i]->defined()) {
+        throw std::invalid_argument("GoFunction: input " + std::to_string(i) +
+                                    " is undefined");
+      }
+      in.push_back(*inputs[i]);
+    }
+    bool is_executable =
+        torch::autograd::GradMode::is_enabled() &&
+        std::any_of(in.begin(), in.end(), [](const at::Tensor &t) {
+          return t.defined() && t.requires_grad();
+        });
+
+    torch::autograd::variable_list raw;
+    {
+      torch::autograd::AutoGradMode mode(false);
+      raw = CallGo(go_forward, handle, in);
+    }
+
+    for (auto &t : raw) {
+      if (!t.defined()) {
+        throw std::runtime_error(
+            "GoFunction: Forward returned an undefined tensor");
+      }
+    }
+
+    std::shared_ptr<torch::autograd::Node> cdata;
+    if (is_executable) {
+      node->set_next_edges(torch::autograd::collect_next_edges(in));
+      for (auto &t : raw) {
+        node->sizes_.push_back(t.sizes().vec());
+        node->options_.push_back(t.options());
+      }
+      cdata = node;
+    }
+    auto out = torch::autograd::_wrap_outputs(in, {}, {}, raw, cdata);
+
+    *outputs = static_cast<Tensor *>(malloc(sizeof(Tensor) * out.size()));
+    for (size_t i = 0; i < out.size(); ++i) {
+      (*outputs)[i] = new at::Tensor(out[i]);
+    }
+    *outputs_len = out.size();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+Tensor Autograd_NewRef(Tensor a) { return new at::Tensor(*a); }
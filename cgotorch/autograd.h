@@ -0,0 +1,72 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Autograd
+////////////////////////////////////////////////////////////////////////////////
+
+// GradMode is thread-local in libtorch.  The Go caller must lock the OS
+// thread around SetGradEnabled and the operations it affects.
+void SetGradEnabled(int8_t enabled);
+int8_t IsGradEnabled();
+
+const char *Tensor_RequiresGrad(Tensor a, int8_t *result);
+const char *Tensor_SetRequiresGrad(Tensor a, int8_t requires_grad);
+const char *Tensor_IsLeaf(Tensor a, int8_t *result);
+
+// Tensor_GradFnName sets name to nullptr if a has no grad_fn.  Otherwise, the
+// caller must free name by calling FreeString.
+const char *Tensor_GradFnName(Tensor a, const char **name);
+
+// grad could be nullptr for a scalar a.
+const char *Tensor_BackwardWith(Tensor a, Tensor grad, int8_t retain_graph,
+                                int8_t create_graph);
+const char *Tensor_ZeroGrad(Tensor a);
+
+// Elements of grad_outputs could be nullptr for scalar outputs.  The caller
+// allocates results with inputs_len elements.  If allow_unused is true, the
+// gradient of an input unused by outputs is nullptr.
+const char *Autograd_Grad(Tensor *outputs, int64_t outputs_len,
+                          Tensor *inputs, int64_t inputs_len,
+                          Tensor *grad_outputs, int8_t retain_graph,
+                          int8_t create_graph, int8_t allow_unused,
+                          Tensor *results);
+
+////////////////////////////////////////////////////////////////////////////////
+// Custom autograd functions implemented in Go
+////////////////////////////////////////////////////////////////////////////////
+
+// AutogradCallback calls the forward or backward method of the Go function
+// identified by handle.  It takes the ownership of the tensors in inputs,
+// whose elements could be nullptr for undefined tensors.  It allocates
+// outputs by malloc and returns the ownership of outputs and its elements to
+// the caller.  It returns nullptr, or an error message allocated by malloc.
+typedef char *(*AutogradCallback)(int64_t handle, Tensor *inputs,
+                                  int64_t inputs_len, Tensor **outputs,
+                                  int64_t *outputs_len);
+// AutogradReleaseCallback frees the tensors saved for backward if all is 0,
+// or forgets the Go function identified by handle if all is 1.
+typedef void (*AutogradReleaseCallback)(int64_t handle, int8_t all);
+
+void Autograd_SetCallbacks(AutogradCallback forward, AutogradCallback backward,
+                           AutogradReleaseCallback release);
+
+// Autograd_Apply calls the forward callback with gradient computation
+// disabled, and connects the results to the autograd graph through a node
+// that calls the backward callback.  The caller must free outputs by calling
+// free.
+const char *Autograd_Apply(int64_t handle, Tensor *inputs, int64_t inputs_len,
+                           Tensor **outputs, int64_t *outputs_len);
+
+// Autograd_NewRef returns a new handle referring to the same tensor as a.
+Tensor Autograd_NewRef(Tensor a);
+
+#ifdef __cplusplus
+}
+#endif
@@ -0,0 +1,109 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/blob.h"
+
+#include <fcntl.h>
+#include <sys/mman.h>
+#include <sys/stat.h>
+#include <unistd.h>
+
+#include <cerrno>
+#include <cstring>
+#include <stdexcept>
+#include <string>
+#include <vector>
+
+namespace {
+BlobDeleter blob_deleter = nullptr;
+
+bool IsLittleEndian() {
+  uint16_t x = 1;
+  return *reinterpret_cast<uint8_t *>(&x) == 1;
+}
+
+std::runtime_error FileError(const std::string &what, const char *path) {
+  return std::runtime_error("FromFile: " + what + " " + path + ": " +
+                            strerror(errno));
+}
+}  // namespace
+
+void Tensor_SetBlobDeleter(BlobDeleter deleter) { blob_deleter = deleter; }
+
+const char *Tensor_FromBlobNoCopy(void *data, int8_t dtype, int64_t *sizes_data,
+                                  int64_t sizes_data_len, int64_t handle,
+                                  Tensor *result) {
+  try {
+    auto t = at::from_blob(data, at::IntArrayRef(sizes_data, sizes_data_len),
+                           [handle](void *) { blob_deleter(handle); },
+                           torch::dtype(at::ScalarType(dtype)));
+    *result = new at::Tensor(t);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// Tensor_FromFile maps the file by MAP_PRIVATE, so processes mapping the same
+// file share the pages in the page cache until they write to the tensors.
+const char *Tensor_FromFile(const char *path, int8_t dtype, int8_t has_sizes,
+                            int64_t *sizes_data, int64_t sizes_data_len,
+                            int64_t offset, Tensor *result) {
+  try {
+    if (!IsLittleEndian()) {
+      throw std::runtime_error("FromFile supports only little-endian hosts");
+    }
+    auto type = static_cast<at::ScalarType>(dtype);
+    int64_t item_size = c10::elementSize(type);
+    if (offset < 0 || offset % item_size != 0) {
+      throw std::invalid_argument("FromFile: offset " + std::to_string(offset) +
+                                  " is not a multiple of the element size");
+    }
+
+    int fd = open(path, O_RDONLY);
+    if (fd < 0) {
+      throw FileError("cannot open", path);
+    }
+    struct stat st;
+    if (fstat(fd, &st) != 0) {
+      close(fd);
+      throw FileError("cannot stat", path);
+    }
+    int64_t length = static_cast<int64_t>(st.st_size) - offset;
+    std::vector<int64_t> sizes = {length / item_size};
+    if (has_sizes != 0) {
+      sizes.assign(sizes_data, sizes_data + sizes_data_len);
+    }
+    int64_t numel = 1;
+    for (auto s : sizes) {
+      numel *= s;
+    }
+    if (length < numel * item_size) {
+      close(fd);
+      throw std::invalid_argument("FromFile: " + std::string(path) +
+                                  " is smaller than the tensor");
+    }
+    if (numel == 0) {
+      close(fd);
+      *result = new at::Tensor(torch::empty(sizes, torch::dtype(type)));
+      return nullptr;
+    }
+
+    size_t mapped = st.st_size;
+    void *p = mmap(nullptr, mapped, PROT_READ | PROT_WRITE, MAP_PRIVATE, fd, 0);
+    close(fd);
+    if (p == MAP_FAILED) {
+      throw FileError("cannot mmap", path);
+    }
+    try {
+      auto t = at::from_blob(static_cast<char *>(p) + offset, sizes,
+                             [p, mapped](void *) { munmap(p, mapped); },
+                             torch::dtype(type));
+      *result = new at::Tensor(t);
+    } catch (...) {
+      munmap(p, mapped);
+      throw;
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
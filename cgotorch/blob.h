@@ -0,0 +1,34 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Zero-copy tensors over external memory
+////////////////////////////////////////////////////////////////////////////////
+
+typedef void (*BlobDeleter)(int64_t handle);
+void Tensor_SetBlobDeleter(BlobDeleter deleter);
+
+// Tensor_FromBlobNoCopy wraps data without copying.  libtorch calls the
+// deleter registered by Tensor_SetBlobDeleter with handle when it frees the
+// storage.
+const char *Tensor_FromBlobNoCopy(void *data, int8_t dtype, int64_t *sizes_data,
+                                  int64_t sizes_data_len, int64_t handle,
+                                  Tensor *result);
+
+// Tensor_FromFile maps the raw little-endian elements in the file at path,
+// starting from offset, into memory.  If has_sizes is 0, the result is
+// 1-dimensional and has all elements in the file; otherwise, sizes_data could
+// be empty for a 0-dimensional result.
+const char *Tensor_FromFile(const char *path, int8_t dtype, int8_t has_sizes,
+                            int64_t *sizes_data, int64_t sizes_data_len,
+                            int64_t offset, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
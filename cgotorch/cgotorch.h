@@ -1,11 +1,26 @@
 /* Copyright 2020, GoTorch Authors */
 #pragma once
+#include "cgotorch/autograd.h"
+#include "cgotorch/blob.h"
+#include "cgotorch/comparison.h"
+#include "cgotorch/complex.h"
 #include "cgotorch/cuda.h"
 #include "cgotorch/device.h"
 #include "cgotorch/functional.h"
+#include "cgotorch/generator.h"
+#include "cgotorch/indexing.h"
 #include "cgotorch/init.h"
+#include "cgotorch/inplace.h"
+#include "cgotorch/linalg.h"
 #include "cgotorch/memory.h"
 #include "cgotorch/optim.h"
 #include "cgotorch/pickle.h"
+#include "cgotorch/pointwise.h"
+#include "cgotorch/quantized.h"
+#include "cgotorch/reduction.h"
+#include "cgotorch/shape.h"
+#include "cgotorch/sorting.h"
+#include "cgotorch/sparse.h"
+#include "cgotorch/spectral.h"
 #include "cgotorch/tensor.h"
 #include "cgotorch/torch.h"
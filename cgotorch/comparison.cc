@@ -0,0 +1,240 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/comparison.h"
+
+#include <ATen/ExpandUtils.h>
+
+#include <stdexcept>
+#include <string>
+
+const char *Gt(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->gt(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Ge(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->ge(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Lt(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->lt(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Le(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->le(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Ne(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->ne(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *EqScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->eq(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *GtScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->gt(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *GeScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->ge(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LtScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->lt(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LeScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->le(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *NeScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->ne(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LogicalAnd(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->logical_and(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LogicalOr(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->logical_or(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LogicalXor(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->logical_xor(*other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LogicalNot(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->logical_not());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *IsNaN(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::isnan(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *IsInf(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::isinf(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *IsFinite(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::isfinite(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Where(Tensor condition, Tensor a, Tensor b, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::where(*condition, *a, *b));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MaskedFill(Tensor a, Tensor mask, double value, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->masked_fill(*mask, value));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MaskedSelect(Tensor a, Tensor mask, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->masked_select(*mask));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Nonzero(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->nonzero());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+namespace {
+
+// CheckBound throws unless bound broadcasts to a without enlarging it, so
+// ClampTensor returns a tensor of the shape of a, like torch.clamp.
+void CheckBound(const at::Tensor &a, const at::Tensor &bound,
+                const char *name) {
+  if (at::infer_size(a.sizes(), bound.sizes()) != a.sizes().vec()) {
+    throw std::invalid_argument(std::string("ClampTensor: ") + name +
+                                " of shape " + c10::str(bound.sizes()) +
+                                " doesn't broadcast to the input of shape " +
+                                c10::str(a.sizes()));
+  }
+}
+
+}  // namespace
+
+// libtorch 1.6 doesn't have clamp with tensor bounds.
+const char *ClampTensor(Tensor a, Tensor min, Tensor max, Tensor *result) {
+  try {
+    auto r = *a;
+    if (min != nullptr) {
+      CheckBound(*a, *min, "min");
+      r = torch::max(r, *min);
+    }
+    if (max != nullptr) {
+      CheckBound(*a, *max, "max");
+      r = torch::min(r, *max);
+    }
+    *result = new at::Tensor(r);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,47 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Comparison, logical and selection operations
+////////////////////////////////////////////////////////////////////////////////
+
+// Comparisons and logical operations return Bool tensors.
+const char *Gt(Tensor a, Tensor other, Tensor *result);
+const char *Ge(Tensor a, Tensor other, Tensor *result);
+const char *Lt(Tensor a, Tensor other, Tensor *result);
+const char *Le(Tensor a, Tensor other, Tensor *result);
+const char *Ne(Tensor a, Tensor other, Tensor *result);
+const char *EqScalar(Tensor a, double other, Tensor *result);
+const char *GtScalar(Tensor a, double other, Tensor *result);
+const char *GeScalar(Tensor a, double other, Tensor *result);
+const char *LtScalar(Tensor a, double other, Tensor *result);
+const char *LeScalar(Tensor a, double other, Tensor *result);
+const char *NeScalar(Tensor a, double other, Tensor *result);
+
+const char *LogicalAnd(Tensor a, Tensor other, Tensor *result);
+const char *LogicalOr(Tensor a, Tensor other, Tensor *result);
+const char *LogicalXor(Tensor a, Tensor other, Tensor *result);
+const char *LogicalNot(Tensor a, Tensor *result);
+
+const char *IsNaN(Tensor a, Tensor *result);
+const char *IsInf(Tensor a, Tensor *result);
+const char *IsFinite(Tensor a, Tensor *result);
+
+const char *Where(Tensor condition, Tensor a, Tensor b, Tensor *result);
+const char *MaskedFill(Tensor a, Tensor mask, double value, Tensor *result);
+const char *MaskedSelect(Tensor a, Tensor mask, Tensor *result);
+const char *Nonzero(Tensor a, Tensor *result);
+
+// ClampTensor clamps a element-wise into [min, max], where min or max could be
+// nullptr for no bound.
+const char *ClampTensor(Tensor a, Tensor min, Tensor max, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
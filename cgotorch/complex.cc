@@ -0,0 +1,75 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/complex.h"
+
+namespace {
+// In libtorch 1.6, some operations on complex tensors return complex tensors
+// with zero imaginary parts, which we convert to real tensors.
+at::Tensor RealValued(const at::Tensor &t) {
+  return t.is_complex() ? at::real(t) : t;
+}
+}  // namespace
+
+const char *Real(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::real(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Imag(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::imag(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Angle(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(RealValued(at::angle(*a)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Conj(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::conj(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *ViewAsReal(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::view_as_real(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *ViewAsComplex(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::view_as_complex(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *ItemComplex128(Tensor a, double *real, double *imag) {
+  try {
+    auto v = a->item<c10::complex<double>>();
+    *real = v.real();
+    *imag = v.imag();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,28 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Complex-valued tensors
+////////////////////////////////////////////////////////////////////////////////
+
+// Real, Imag and Angle return real-valued tensors for complex inputs.
+const char *Real(Tensor a, Tensor *result);
+const char *Imag(Tensor a, Tensor *result);
+const char *Angle(Tensor a, Tensor *result);
+const char *Conj(Tensor a, Tensor *result);
+
+// ViewAsReal and ViewAsComplex share the storage of a.
+const char *ViewAsReal(Tensor a, Tensor *result);
+const char *ViewAsComplex(Tensor a, Tensor *result);
+
+const char *ItemComplex128(Tensor a, double *real, double *imag);
+
+#ifdef __cplusplus
+}
+#endif
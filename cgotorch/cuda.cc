@@ -9,6 +9,8 @@ bool IsCUDAAvailable() { return torch::cuda::is_available(); }
 
 bool IsCUDNNAvailable() { return torch::cuda::cudnn_is_available(); }
 
+int64_t CUDA_DeviceCount() { return torch::cuda::device_count(); }
+
 const char *CUDA_GetCurrentCUDAStream(CUDAStream *stream, Device *device) {
 #ifdef WITH_CUDA
   try {
@@ -12,6 +12,7 @@ extern "C" {
 ////////////////////////////////////////////////////////////////////////////////
 bool IsCUDAAvailable();
 bool IsCUDNNAvailable();
+int64_t CUDA_DeviceCount();
 const char *CUDA_GetCurrentCUDAStream(CUDAStream *stream, Device *device);
 const char *CUDA_SetCurrentCUDAStream(CUDAStream stream);
 const char *CUDA_GetCUDAStreamFromPool(CUDAStream *stream, Device *device);
@@ -1,22 +1,46 @@
 // Copyright 2020, GoTorch Authors
 #include "cgotorch/device.h"
 
+#include <cstdio>
 #include <string>
-#include <unordered_map>
-
-std::unordered_map<std::string, torch::DeviceType> device_type_map = {
-    {"cpu", torch::kCPU}, {"cuda", torch::kCUDA}};
 
 const char *Torch_Device(const char *device_type, Device *device) {
   try {
-    if (device_type_map.count(std::string(device_type)) == 0) {
-      return exception_str("Excepted one of cpu, cuda device type.");
-    }
-    *device = new torch::Device(device_type_map[std::string(device_type)]);
+    *device = new torch::Device(std::string(device_type));
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+  return nullptr;
+}
+
+const char *Torch_DeviceOf(int8_t type, int8_t index, Device *device) {
+  try {
+    *device = new torch::Device(static_cast<c10::DeviceType>(type), index);
   } catch (const std::exception &e) {
     return exception_str(e.what());
   }
   return nullptr;
 }
 
+void Device_Close(Device device) { delete device; }
+
+void Device_Info(Device device, int8_t *type, int8_t *index) {
+  *type = static_cast<int8_t>(device->type());
+  *index = device->index();
+}
+
+const char *Device_TypeName(int8_t type) {
+  std::string s =
+      c10::DeviceTypeName(static_cast<c10::DeviceType>(type), true);
+  char *r = new char[s.size() + 1];
+  snprintf(r, s.size() + 1, "%s", s.c_str());
+  return r;
+}
+
+void Tensor_Device(Tensor a, int8_t *type, int8_t *index) {
+  auto d = a->device();
+  *type = static_cast<int8_t>(d.type());
+  *index = d.index();
+}
+
 void SetNumThreads(int32_t n) { torch::set_num_threads(n); }
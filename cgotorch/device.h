@@ -12,7 +12,16 @@ extern "C" {
 // Device
 ////////////////////////////////////////////////////////////////////////////////
 
+// Torch_Device parses a device string like "cpu", "cuda", or "cuda:1".
 const char *Torch_Device(const char *device_type, Device *device);
+const char *Torch_DeviceOf(int8_t type, int8_t index, Device *device);
+void Device_Close(Device device);
+// Device_Info returns the c10::DeviceType and the index, which is -1 if not
+// specified, of device.
+void Device_Info(Device device, int8_t *type, int8_t *index);
+// The caller must free the returned name by calling FreeString.
+const char *Device_TypeName(int8_t type);
+void Tensor_Device(Tensor a, int8_t *type, int8_t *index);
 void SetNumThreads(int32_t n);
 
 #ifdef __cplusplus
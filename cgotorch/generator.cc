@@ -0,0 +1,151 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/generator.h"
+
+#include <ATen/CPUGeneratorImpl.h>
+
+#include <cstring>
+#include <mutex>
+
+namespace {
+
+// GeneratorState is the state of at::CPUGeneratorImpl, including the cached
+// normal samples.  libtorch 1.6 has no Generator::get_state, so we serialize
+// the state ourselves.
+struct GeneratorState {
+  at::mt19937_data_pod engine;
+  int8_t has_next_float;
+  int8_t has_next_double;
+  float next_float;
+  double next_double;
+};
+
+}  // namespace
+
+const char *NewGenerator(int64_t seed, Generator *result) {
+  try {
+    *result = new at::Generator(at::detail::createCPUGenerator(seed));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+Generator DefaultGenerator() {
+  return new at::Generator(at::detail::getDefaultCPUGenerator());
+}
+
+void Generator_Close(Generator g) { delete g; }
+
+void Generator_ManualSeed(Generator g, int64_t seed) {
+  std::lock_guard<std::mutex> lock(g->mutex());
+  g->set_current_seed(seed);
+}
+
+int64_t Generator_Seed(Generator g) { return g->current_seed(); }
+
+int64_t Generator_StateSize() { return sizeof(GeneratorState); }
+
+const char *Generator_GetState(Generator g, void *state) {
+  try {
+    std::lock_guard<std::mutex> lock(g->mutex());
+    auto impl = at::check_generator<at::CPUGeneratorImpl>(*g);
+    GeneratorState s;
+    memset(&s, 0, sizeof(s));
+    s.engine = impl->engine().data();
+    auto f = impl->next_float_normal_sample();
+    auto d = impl->next_double_normal_sample();
+    s.has_next_float = f.has_value();
+    s.has_next_double = d.has_value();
+    if (f.has_value()) s.next_float = *f;
+    if (d.has_value()) s.next_double = *d;
+    memcpy(state, &s, sizeof(s));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Generator_SetState(Generator g, const void *state, int64_t len) {
+  try {
+    if (len != static_cast<int64_t>(sizeof(GeneratorState))) {
+      return exception_str("invalid generator state size");
+    }
+    GeneratorState s;
+    memcpy(&s, state, sizeof(s));
+    std::lock_guard<std::mutex> lock(g->mutex());
+    auto impl = at::check_generator<at::CPUGeneratorImpl>(*g);
+    at::mt19937 engine;
+    engine.set_data(s.engine);
+    impl->set_engine(engine);
+    impl->set_next_float_normal_sample(
+        s.has_next_float ? c10::optional<float>(s.next_float) : c10::nullopt);
+    impl->set_next_double_normal_sample(
+        s.has_next_double ? c10::optional<double>(s.next_double)
+                          : c10::nullopt);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *RandInt(int64_t low, int64_t high, int64_t *size, int64_t length,
+                    Generator gen, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::randint(
+        low, high, torch::IntArrayRef(size, length), OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *RandPerm(int64_t n, Generator gen, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::randperm(n, OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Bernoulli(Tensor probs, Generator gen, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::bernoulli(*probs, OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Multinomial(Tensor probs, int64_t num_samples, int8_t replacement,
+                        Generator gen, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::multinomial(
+        *probs, num_samples, replacement != 0, OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Normal(double mean, double std, int64_t *size, int64_t length,
+                   Generator gen, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::normal(
+        mean, std, torch::IntArrayRef(size, length), OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *NormalTensor(Tensor mean, Tensor std, Generator gen,
+                         Tensor *result) {
+  try {
+    *result =
+        new at::Tensor(torch::normal(*mean, *std, OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
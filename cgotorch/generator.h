@@ -0,0 +1,50 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Random number generators and sampling
+////////////////////////////////////////////////////////////////////////////////
+
+// Functions taking a Generator use the default CPU generator if it is nullptr.
+
+const char *NewGenerator(int64_t seed, Generator *result);
+Generator DefaultGenerator();
+void Generator_Close(Generator g);
+void Generator_ManualSeed(Generator g, int64_t seed);
+int64_t Generator_Seed(Generator g);
+// The state of a generator is a byte array of Generator_StateSize() bytes.
+int64_t Generator_StateSize();
+const char *Generator_GetState(Generator g, void *state);
+const char *Generator_SetState(Generator g, const void *state, int64_t len);
+
+// torch.randint
+const char *RandInt(int64_t low, int64_t high, int64_t *size, int64_t length,
+                    Generator gen, Tensor *result);
+// torch.randperm
+const char *RandPerm(int64_t n, Generator gen, Tensor *result);
+// torch.bernoulli
+const char *Bernoulli(Tensor probs, Generator gen, Tensor *result);
+// torch.multinomial
+const char *Multinomial(Tensor probs, int64_t num_samples, int8_t replacement,
+                        Generator gen, Tensor *result);
+// torch.normal(mean, std, size)
+const char *Normal(double mean, double std, int64_t *size, int64_t length,
+                   Generator gen, Tensor *result);
+// torch.normal(mean, std) with tensors mean and std
+const char *NormalTensor(Tensor mean, Tensor std, Generator gen,
+                         Tensor *result);
+
+#ifdef __cplusplus
+}
+
+inline c10::optional<at::Generator> OptionalGenerator(Generator g) {
+  if (g == nullptr) return c10::nullopt;
+  return *g;
+}
+#endif
@@ -0,0 +1,91 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/indexing.h"
+
+#include "cgotorch/inplace.h"
+
+#include <stdexcept>
+#include <vector>
+
+std::vector<at::indexing::TensorIndex> tensor_indices(int8_t *kinds,
+                                                      int64_t *ints,
+                                                      Tensor *tensors,
+                                                      int64_t len) {
+  std::vector<at::indexing::TensorIndex> indices;
+  for (int64_t i = 0; i < len; i++) {
+    int64_t *n = ints + 3 * i;
+    switch (kinds[i]) {
+      case 0:
+        indices.emplace_back(n[0]);
+        break;
+      case 1:
+        indices.emplace_back(at::indexing::Slice(n[0], n[1], n[2]));
+        break;
+      case 2:
+        indices.emplace_back(at::indexing::None);
+        break;
+      case 3:
+        indices.emplace_back(at::indexing::Ellipsis);
+        break;
+      case 4:
+        indices.emplace_back(n[0] != 0);
+        break;
+      case 5:
+        indices.emplace_back(*tensors[i]);
+        break;
+      default:
+        throw std::invalid_argument("unknown kind of tensor index");
+    }
+  }
+  return indices;
+}
+
+const char *Tensor_IndexWith(Tensor a, int8_t *kinds, int64_t *ints,
+                             Tensor *tensors, int64_t len, Tensor *result) {
+  try {
+    auto indices = tensor_indices(kinds, ints, tensors, len);
+    *result = new at::Tensor(a->index(indices));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_IndexPut_(Tensor a, int8_t *kinds, int64_t *ints,
+                             Tensor *tensors, int64_t len, Tensor value) {
+  try {
+    auto indices = tensor_indices(kinds, ints, tensors, len);
+    CheckMutable(*a).index_put_(indices, *value);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Narrow(Tensor a, int64_t dim, int64_t start, int64_t length,
+                   Tensor *result) {
+  try {
+    *result = new at::Tensor(a->narrow(dim, start, length));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Select(Tensor a, int64_t dim, int64_t index, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->select(dim, index));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Slice(Tensor a, int64_t dim, int64_t start, int64_t end,
+                  int64_t step, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->slice(dim, start, end, step));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
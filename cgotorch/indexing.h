@@ -0,0 +1,34 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Tensor indexing, at::indexing
+////////////////////////////////////////////////////////////////////////////////
+
+// The i-th index is described by kinds[i], ints[3*i:3*i+3], and tensors[i]:
+//   kinds[i] == 0: an integer ints[3*i]
+//   kinds[i] == 1: a slice(ints[3*i], ints[3*i+1], ints[3*i+2])
+//   kinds[i] == 2: None, which inserts a new dimension
+//   kinds[i] == 3: Ellipsis
+//   kinds[i] == 4: a boolean ints[3*i] != 0
+//   kinds[i] == 5: a tensor tensors[i] of indices or a boolean mask
+const char *Tensor_IndexWith(Tensor a, int8_t *kinds, int64_t *ints,
+                             Tensor *tensors, int64_t len, Tensor *result);
+const char *Tensor_IndexPut_(Tensor a, int8_t *kinds, int64_t *ints,
+                             Tensor *tensors, int64_t len, Tensor value);
+
+const char *Narrow(Tensor a, int64_t dim, int64_t start, int64_t length,
+                   Tensor *result);
+const char *Select(Tensor a, int64_t dim, int64_t index, Tensor *result);
+const char *Slice(Tensor a, int64_t dim, int64_t start, int64_t end,
+                  int64_t step, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
@@ -1,9 +1,12 @@
 // Copyright 2020, GoTorch Authors
 #include "cgotorch/init.h"
 
+#include <cmath>
 #include <string>
 #include <unordered_map>
 
+#include "cgotorch/generator.h"
+
 std::unordered_map<std::string, torch::nn::init::FanModeType> fan_mode_map = {
     {"fan_in", torch::kFanIn},
     {"fan_out", torch::kFanOut},
@@ -42,18 +45,22 @@ const char *Ones_(Tensor *tensor) {
   }
 }
 
-const char *Uniform_(Tensor *tensor, double low, double high) {
+// torch::nn::init doesn't take generators, so we follow its implementation
+// with generators.
+const char *Uniform_(Tensor *tensor, double low, double high, Generator gen) {
   try {
-    torch::nn::init::uniform_(**tensor, low, high);
+    torch::NoGradGuard guard;
+    (*tensor)->uniform_(low, high, OptionalGenerator(gen));
     return nullptr;
   } catch (const std::exception &e) {
     return exception_str(e.what());
   }
 }
 
-const char *Normal_(Tensor *tensor, double mean, double std) {
+const char *Normal_(Tensor *tensor, double mean, double std, Generator gen) {
   try {
-    torch::nn::init::normal_(**tensor, mean, std);
+    torch::NoGradGuard guard;
+    (*tensor)->normal_(mean, std, OptionalGenerator(gen));
     return nullptr;
   } catch (const std::exception &e) {
     return exception_str(e.what());
@@ -61,11 +68,23 @@ const char *Normal_(Tensor *tensor, double mean, double std) {
 }
 
 const char *KaimingUniform_(double a, const char *fan_mod,
-                            const char *non_linearity, Tensor *tensor) {
+                            const char *non_linearity, Tensor *tensor,
+                            Generator gen) {
   try {
-    torch::nn::init::kaiming_uniform_(
-        **tensor, a, fan_mode_map[std::string(fan_mod)],
-        non_linearity_map[std::string(non_linearity)]);
+    if (gen == nullptr) {
+      torch::nn::init::kaiming_uniform_(
+          **tensor, a, fan_mode_map[std::string(fan_mod)],
+          non_linearity_map[std::string(non_linearity)]);
+      return nullptr;
+    }
+    auto fans = torch::nn::init::_calculate_fan_in_and_fan_out(**tensor);
+    auto fan = std::string(fan_mod) == "fan_in" ? std::get<0>(fans)
+                                                : std::get<1>(fans);
+    auto gain = torch::nn::init::calculate_gain(
+        non_linearity_map[std::string(non_linearity)], a);
+    auto bound = std::sqrt(3.0) * gain / std::sqrt(fan);
+    torch::NoGradGuard guard;
+    (*tensor)->uniform_(-bound, bound, OptionalGenerator(gen));
     return nullptr;
   } catch (const std::exception &e) {
     return exception_str(e.what());
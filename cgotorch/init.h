@@ -16,13 +16,15 @@ extern "C" {
 const char *Zeros_(Tensor *tensor);
 // torch.nn.init.ones_
 const char *Ones_(Tensor *tensor);
+// The following initializers use the default generator if gen is nullptr.
 // torch.nn.init.uniform_
-const char *Uniform_(Tensor *tensor, double low, double high);
+const char *Uniform_(Tensor *tensor, double low, double high, Generator gen);
 // torch.nn.init.normal_
-const char *Normal_(Tensor *tensor, double mean, double std);
+const char *Normal_(Tensor *tensor, double mean, double std, Generator gen);
 // torch.nn.init.kaiming_uniform_
 const char *KaimingUniform_(double a, const char *fan_mod,
-                            const char *non_linearity, Tensor *tensor);
+                            const char *non_linearity, Tensor *tensor,
+                            Generator gen);
 const char *CalculateFanInAndFanOut(Tensor tensor, int64_t *fan_in,
                                     int64_t *fan_out);
 
@@ -0,0 +1,103 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/inplace.h"
+
+#include "cgotorch/generator.h"
+
+const char *Tensor_Fill_(Tensor a, double value, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).fill_(value));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Zero_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).zero_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Copy_(Tensor a, Tensor src, int8_t non_blocking,
+                         Tensor *result) {
+  try {
+    *result =
+        new at::Tensor(CheckMutable(*a).copy_(*src, non_blocking != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Resize_(Tensor a, int64_t *sizes, int64_t len,
+                           Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        CheckMutable(*a).resize_(at::IntArrayRef(sizes, len)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Uniform_(Tensor a, double low, double high, Generator gen,
+                            Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        CheckMutable(*a).uniform_(low, high, OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Normal_(Tensor a, double mean, double std, Generator gen,
+                           Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        CheckMutable(*a).normal_(mean, std, OptionalGenerator(gen)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Relu_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::relu_(CheckMutable(*a)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LeakyRelu_(Tensor a, double negative_slope, Tensor *result) {
+  try {
+    *result =
+        new at::Tensor(at::leaky_relu_(CheckMutable(*a), negative_slope));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tanh_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).tanh_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Sigmoid_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).sigmoid_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
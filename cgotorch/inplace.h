@@ -0,0 +1,57 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// In-place mutations
+////////////////////////////////////////////////////////////////////////////////
+
+// The following functions fail, rather than let autograd compute wrong
+// gradients, if a is a leaf tensor requiring grad and grad mode is enabled.
+// They return a new reference to a in result.
+
+// torch.Tensor.fill_
+const char *Tensor_Fill_(Tensor a, double value, Tensor *result);
+// torch.Tensor.zero_
+const char *Tensor_Zero_(Tensor a, Tensor *result);
+// torch.Tensor.copy_, which copies across devices and broadcasts src
+const char *Tensor_Copy_(Tensor a, Tensor src, int8_t non_blocking,
+                         Tensor *result);
+// torch.Tensor.resize_
+const char *Tensor_Resize_(Tensor a, int64_t *sizes, int64_t len,
+                           Tensor *result);
+// torch.Tensor.uniform_ uses the default generator if gen is nullptr.
+const char *Tensor_Uniform_(Tensor a, double low, double high, Generator gen,
+                            Tensor *result);
+// torch.Tensor.normal_ uses the default generator if gen is nullptr.
+const char *Tensor_Normal_(Tensor a, double mean, double std, Generator gen,
+                           Tensor *result);
+
+// In-place activations
+const char *Relu_(Tensor a, Tensor *result);
+const char *LeakyRelu_(Tensor a, double negative_slope, Tensor *result);
+const char *Tanh_(Tensor a, Tensor *result);
+const char *Sigmoid_(Tensor a, Tensor *result);
+
+#ifdef __cplusplus
+}
+
+#include <stdexcept>
+
+// CheckMutable throws if writing to a in-place breaks autograd.  libtorch
+// checks most in-place operations in a similar way, but not resize_, and its
+// message doesn't say how to fix the program.
+inline at::Tensor &CheckMutable(at::Tensor &a) {
+  if (a.is_leaf() && a.requires_grad() && at::GradMode::is_enabled()) {
+    throw std::invalid_argument(
+        "a leaf tensor that requires grad cannot be modified in-place "
+        "unless gradient computation is disabled, e.g., by NoGrad");
+  }
+  return a;
+}
+#endif
@@ -0,0 +1,137 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/linalg.h"
+
+#include <string>
+#include <vector>
+
+const char *Matmul(Tensor a, Tensor b, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::matmul(*a, *b));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *BMM(Tensor a, Tensor b, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::bmm(*a, *b));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Einsum(const char *equation, Tensor *tensors, int64_t tensors_size,
+                   Tensor *result) {
+  try {
+    std::vector<torch::Tensor> data;
+    while (data.size() < tensors_size) data.push_back(**tensors++);
+    *result = new at::Tensor(torch::einsum(std::string(equation), data));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// libtorch 1.6 calls torch.outer by its old name torch.ger.
+const char *Outer(Tensor a, Tensor b, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::ger(*a, *b));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Dot(Tensor a, Tensor b, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::dot(*a, *b));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Inverse(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::inverse(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Det(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::det(*a));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Solve(Tensor A, Tensor B, Tensor *result) {
+  try {
+    // torch::solve(B, A) returns the solution and the LU factorization of A.
+    auto outputs = torch::solve(*B, *A);
+    *result = new at::Tensor(std::get<0>(outputs));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Cholesky(Tensor a, int8_t upper, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::cholesky(*a, upper != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *QR(Tensor a, int8_t some, Tensor *q, Tensor *r) {
+  try {
+    auto outputs = torch::qr(*a, some != 0);
+    *q = new at::Tensor(std::get<0>(outputs));
+    *r = new at::Tensor(std::get<1>(outputs));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *SVD(Tensor a, int8_t some, Tensor *u, Tensor *s, Tensor *v) {
+  try {
+    auto outputs = torch::svd(*a, some != 0);
+    *u = new at::Tensor(std::get<0>(outputs));
+    *s = new at::Tensor(std::get<1>(outputs));
+    *v = new at::Tensor(std::get<2>(outputs));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// libtorch 1.6 doesn't have torch.linalg.eigh, but torch.symeig does the same.
+const char *Eigh(Tensor a, int8_t upper, Tensor *eigenvalues,
+                 Tensor *eigenvectors) {
+  try {
+    auto outputs = torch::symeig(*a, /*eigenvectors=*/true, upper != 0);
+    *eigenvalues = new at::Tensor(std::get<0>(outputs));
+    *eigenvectors = new at::Tensor(std::get<1>(outputs));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Pinv(Tensor a, double rcond, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::pinverse(*a, rcond));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,34 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Linear algebra
+////////////////////////////////////////////////////////////////////////////////
+
+const char *Matmul(Tensor a, Tensor b, Tensor *result);
+const char *BMM(Tensor a, Tensor b, Tensor *result);
+const char *Einsum(const char *equation, Tensor *tensors, int64_t tensors_size,
+                   Tensor *result);
+const char *Outer(Tensor a, Tensor b, Tensor *result);
+const char *Dot(Tensor a, Tensor b, Tensor *result);
+
+const char *Inverse(Tensor a, Tensor *result);
+const char *Det(Tensor a, Tensor *result);
+// Solve computes the solution X of AX = B.
+const char *Solve(Tensor A, Tensor B, Tensor *result);
+const char *Cholesky(Tensor a, int8_t upper, Tensor *result);
+const char *QR(Tensor a, int8_t some, Tensor *q, Tensor *r);
+const char *SVD(Tensor a, int8_t some, Tensor *u, Tensor *s, Tensor *v);
+const char *Eigh(Tensor a, int8_t upper, Tensor *eigenvalues,
+                 Tensor *eigenvectors);
+const char *Pinv(Tensor a, double rcond, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
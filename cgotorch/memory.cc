@@ -8,3 +8,22 @@ uint8_t GCPrepared() { return gcPrepared; }
 void PrepareGC() { gcPrepared = true; }
 
 void FinishGC() { gcPrepared = false; }
+
+void Tensor_MemoryInfo(Tensor a, int8_t *device_type, int8_t *device_index,
+                       int8_t *dtype, int64_t *nbytes) {
+  if (!a->defined()) {
+    *device_type = static_cast<int8_t>(c10::DeviceType::CPU);
+    *device_index = -1;
+    *dtype = -1;
+    *nbytes = 0;
+    return;
+  }
+  *device_type = static_cast<int8_t>(a->device().type());
+  *device_index = a->device().index();
+  *dtype = static_cast<int8_t>(a->scalar_type());
+  if (a->is_sparse()) {
+    *nbytes = a->_indices().nbytes() + a->_values().nbytes();
+  } else {
+    *nbytes = a->numel() * a->element_size();
+  }
+}
@@ -1,7 +1,7 @@
 /* Copyright 2020, GoTorch Authors */
 #pragma once
 
-#include <stdint.h>
+#include "cgotorch/torchdef.h"
 
 #ifdef __cplusplus
 extern "C" {
@@ -15,6 +15,11 @@ uint8_t GCPrepared();
 void PrepareGC();
 void FinishGC();
 
+// Tensor_MemoryInfo returns the device type, the device index, the dtype and
+// the number of bytes of the elements of a, for memory accounting.
+void Tensor_MemoryInfo(Tensor a, int8_t *device_type, int8_t *device_index,
+                       int8_t *dtype, int64_t *nbytes);
+
 #ifdef __cplusplus
 }
 #endif
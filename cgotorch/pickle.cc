@@ -1,15 +1,20 @@
 // Copyright 2020, GoTorch Authors
 #include "cgotorch/pickle.h"
 
+#include <stdexcept>
 #include <vector>
 
 const char* Tensor_Encode(Tensor a, ByteBuffer* r) {
   try {
     *r = new (std::vector<char>);
-    if (a->is_cuda() || a->is_hip()) {
-      **r = torch::pickle_save(a->cpu());  // Move GPU tensors to CPU.
+    auto t = a->is_cuda() || a->is_hip() ? a->cpu() : *a;  // Move to CPU.
+    if (t.is_sparse()) {
+      // Encode a sparse tensor as the tuple (indices, values, shape).
+      auto c = t.coalesce();
+      **r = torch::pickle_save(c10::ivalue::Tuple::create(
+          {c.indices(), c.values(), c10::IValue(c.sizes().vec())}));
     } else {
-      **r = torch::pickle_save(*a);
+      **r = torch::pickle_save(t);
     }
     return nullptr;
   } catch (const std::exception& e) {
@@ -31,8 +36,20 @@ const char* Tensor_Decode(void* addr, int64_t size, Tensor* r) {
   try {
     auto data = static_cast<const char*>(addr);
     std::vector<char> buf(data, data + static_cast<int>(size));
-    *r = new at::Tensor();
-    **r = torch::pickle_load(buf).toTensor();
+    auto v = torch::pickle_load(buf);
+    if (v.isTuple()) {
+      auto e = v.toTuple()->elements();
+      if (e.size() != 3 || !e[0].isTensor() || !e[1].isTensor() ||
+          !e[2].isIntList()) {
+        throw std::invalid_argument(
+            "Tensor_Decode: a tuple must be (indices, values, shape) of a "
+            "sparse tensor");
+      }
+      *r = new at::Tensor(at::sparse_coo_tensor(
+          e[0].toTensor(), e[1].toTensor(), e[2].toIntVector()));
+    } else {
+      *r = new at::Tensor(v.toTensor());
+    }
     return nullptr;
   } catch (const std::exception& e) {
     return exception_str(e.what());
@@ -0,0 +1,403 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/pointwise.h"
+
+#include "cgotorch/inplace.h"
+
+const char *Exp(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->exp());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Exp_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).exp_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Log(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->log());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Log_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).log_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Log1p(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->log1p());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Log1p_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).log1p_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Sqrt(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->sqrt());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Sqrt_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).sqrt_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Rsqrt(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->rsqrt());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Rsqrt_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).rsqrt_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Abs(Tensor a, Tensor *result) {
+  try {
+    // libtorch 1.6 returns complex absolute values of complex tensors.
+    auto r = a->abs();
+    *result = new at::Tensor(r.is_complex() ? at::real(r) : r);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Abs_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).abs_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Neg(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->neg());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Neg_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).neg_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Sign(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->sign());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Sign_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).sign_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Floor(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->floor());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Floor_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).floor_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Ceil(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->ceil());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Ceil_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).ceil_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Round(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->round());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Round_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).round_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Sin(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->sin());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Sin_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).sin_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Cos(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->cos());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Cos_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).cos_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Reciprocal(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->reciprocal());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Reciprocal_(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).reciprocal_());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *AddScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->add(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *AddScalar_(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).add_(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *SubScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->sub(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *SubScalar_(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).sub_(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MulScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->mul(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MulScalar_(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).mul_(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *DivScalar(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->div(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *DivScalar_(Tensor a, double other, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).div_(other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+const char *Pow(Tensor a, Tensor exponent, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->pow(*exponent));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Pow_(Tensor a, Tensor exponent, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).pow_(*exponent));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *PowScalar(Tensor a, double exponent, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->pow(exponent));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *PowScalar_(Tensor a, double exponent, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).pow_(exponent));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Clamp(Tensor a, double min, double max, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->clamp(min, max));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Clamp_(Tensor a, double min, double max, Tensor *result) {
+  try {
+    *result = new at::Tensor(CheckMutable(*a).clamp_(min, max));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// libtorch 1.6 doesn't have torch::maximum, but the binary overload of
+// torch::max does the same.
+const char *Maximum(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::max(*a, *other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Minimum(Tensor a, Tensor other, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::min(*a, *other));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
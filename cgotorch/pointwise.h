@@ -0,0 +1,70 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Element-wise (pointwise) math operations
+////////////////////////////////////////////////////////////////////////////////
+
+// Element-wise unary operations and their in-place versions
+const char *Exp(Tensor a, Tensor *result);
+const char *Exp_(Tensor a, Tensor *result);
+const char *Log(Tensor a, Tensor *result);
+const char *Log_(Tensor a, Tensor *result);
+const char *Log1p(Tensor a, Tensor *result);
+const char *Log1p_(Tensor a, Tensor *result);
+const char *Sqrt(Tensor a, Tensor *result);
+const char *Sqrt_(Tensor a, Tensor *result);
+const char *Rsqrt(Tensor a, Tensor *result);
+const char *Rsqrt_(Tensor a, Tensor *result);
+const char *Abs(Tensor a, Tensor *result);
+const char *Abs_(Tensor a, Tensor *result);
+const char *Neg(Tensor a, Tensor *result);
+const char *Neg_(Tensor a, Tensor *result);
+const char *Sign(Tensor a, Tensor *result);
+const char *Sign_(Tensor a, Tensor *result);
+const char *Floor(Tensor a, Tensor *result);
+const char *Floor_(Tensor a, Tensor *result);
+const char *Ceil(Tensor a, Tensor *result);
+const char *Ceil_(Tensor a, Tensor *result);
+const char *Round(Tensor a, Tensor *result);
+const char *Round_(Tensor a, Tensor *result);
+const char *Sin(Tensor a, Tensor *result);
+const char *Sin_(Tensor a, Tensor *result);
+const char *Cos(Tensor a, Tensor *result);
+const char *Cos_(Tensor a, Tensor *result);
+const char *Reciprocal(Tensor a, Tensor *result);
+const char *Reciprocal_(Tensor a, Tensor *result);
+
+// Arithmetic operations between a tensor and a scalar
+const char *AddScalar(Tensor a, double other, Tensor *result);
+const char *AddScalar_(Tensor a, double other, Tensor *result);
+const char *SubScalar(Tensor a, double other, Tensor *result);
+const char *SubScalar_(Tensor a, double other, Tensor *result);
+const char *MulScalar(Tensor a, double other, Tensor *result);
+const char *MulScalar_(Tensor a, double other, Tensor *result);
+const char *DivScalar(Tensor a, double other, Tensor *result);
+const char *DivScalar_(Tensor a, double other, Tensor *result);
+
+// torch.pow with a tensor or a scalar exponent
+const char *Pow(Tensor a, Tensor exponent, Tensor *result);
+const char *Pow_(Tensor a, Tensor exponent, Tensor *result);
+const char *PowScalar(Tensor a, double exponent, Tensor *result);
+const char *PowScalar_(Tensor a, double exponent, Tensor *result);
+
+// torch.clamp
+const char *Clamp(Tensor a, double min, double max, Tensor *result);
+const char *Clamp_(Tensor a, double min, double max, Tensor *result);
+
+// torch.max(a, other) and torch.min(a, other)
+const char *Maximum(Tensor a, Tensor other, Tensor *result);
+const char *Minimum(Tensor a, Tensor other, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
@@ -0,0 +1,160 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/quantized.h"
+
+#include <stdexcept>
+#include <tuple>
+
+const char *QuantizePerTensor(Tensor a, double scale, int64_t zero_point,
+                              int8_t dtype, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::quantize_per_tensor(
+        *a, scale, zero_point, static_cast<at::ScalarType>(dtype)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *QuantizePerChannel(Tensor a, Tensor scales, Tensor zero_points,
+                               int64_t axis, int8_t dtype, Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        at::quantize_per_channel(*a, *scales, *zero_points, axis,
+                                 static_cast<at::ScalarType>(dtype)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Dequantize(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->dequantize());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *IntRepr(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->int_repr());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_IsQuantized(Tensor a, int8_t *result) {
+  try {
+    *result = a->is_quantized() ? 1 : 0;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_QScale(Tensor a, double *result) {
+  try {
+    *result = a->q_scale();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_QZeroPoint(Tensor a, int64_t *result) {
+  try {
+    *result = a->q_zero_point();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_QPerChannelScales(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->q_per_channel_scales());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_QPerChannelZeroPoints(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->q_per_channel_zero_points());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_QPerChannelAxis(Tensor a, int64_t *result) {
+  try {
+    *result = a->q_per_channel_axis();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+int8_t FBGEMM_IsCPUSupported() {
+  return at::fbgemm_is_cpu_supported() ? 1 : 0;
+}
+
+const char *QuantizeLinearWeight(Tensor weight, Tensor *result) {
+  try {
+    at::Tensor w;
+    double scale;
+    int64_t zero_point;
+    std::tie(w, std::ignore, scale, zero_point) =
+        at::fbgemm_linear_quantize_weight(weight->contiguous());
+    *result = new at::Tensor(
+        at::_make_per_tensor_quantized_tensor(w, scale, zero_point));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// The column offsets follow calc_col_offsets_transpose in
+// ATen/native/QuantizedLinear.cpp, which fbgemm_linear_quantize_weight calls.
+const char *PackLinearWeight(Tensor weight, Tensor *int_weight, Tensor *packed,
+                             Tensor *col_offsets) {
+  try {
+    if (weight->scalar_type() != at::kQInt8 || weight->dim() != 2) {
+      throw std::invalid_argument(
+          "PackLinearWeight expects a 2-dimensional QInt8 weight");
+    }
+    auto w = weight->int_repr().contiguous();
+    auto offsets = w.sum(1, /*keepdim=*/false, at::kInt) -
+                   static_cast<int32_t>(weight->q_zero_point() * w.size(1));
+    *int_weight = new at::Tensor(w);
+    *packed = new at::Tensor(at::fbgemm_pack_quantized_matrix(w));
+    *col_offsets = new at::Tensor(offsets);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+void PackedWeightKey(Tensor weight, void **data, int64_t *version) {
+  *data = weight->defined() ? weight->data_ptr() : nullptr;
+  *version = weight->defined() ? weight->_version() : 0;
+}
+
+const char *DynamicQuantizedLinear(Tensor input, Tensor int_weight,
+                                   Tensor packed, Tensor col_offsets,
+                                   double scale, int64_t zero_point,
+                                   Tensor bias, Tensor *result) {
+  try {
+    auto b = bias ? *bias : at::zeros({int_weight->size(0)}, input->options());
+    *result = new at::Tensor(at::fbgemm_linear_int8_weight_fp32_activation(
+        input->contiguous(), *int_weight, *packed, *col_offsets, scale,
+        zero_point, b));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,65 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Quantized tensors
+////////////////////////////////////////////////////////////////////////////////
+
+// torch.quantize_per_tensor
+const char *QuantizePerTensor(Tensor a, double scale, int64_t zero_point,
+                              int8_t dtype, Tensor *result);
+// torch.quantize_per_channel
+const char *QuantizePerChannel(Tensor a, Tensor scales, Tensor zero_points,
+                               int64_t axis, int8_t dtype, Tensor *result);
+// torch.Tensor.dequantize
+const char *Dequantize(Tensor a, Tensor *result);
+// torch.Tensor.int_repr
+const char *IntRepr(Tensor a, Tensor *result);
+
+// torch.Tensor.is_quantized
+const char *Tensor_IsQuantized(Tensor a, int8_t *result);
+// torch.Tensor.q_scale and torch.Tensor.q_zero_point of tensors quantized per
+// tensor
+const char *Tensor_QScale(Tensor a, double *result);
+const char *Tensor_QZeroPoint(Tensor a, int64_t *result);
+// torch.Tensor.q_per_channel_scales, q_per_channel_zero_points, and
+// q_per_channel_axis of tensors quantized per channel
+const char *Tensor_QPerChannelScales(Tensor a, Tensor *result);
+const char *Tensor_QPerChannelZeroPoints(Tensor a, Tensor *result);
+const char *Tensor_QPerChannelAxis(Tensor a, int64_t *result);
+
+////////////////////////////////////////////////////////////////////////////////
+// Dynamic quantization of linear layers by FBGEMM
+////////////////////////////////////////////////////////////////////////////////
+
+// FBGEMM_IsCPUSupported returns non-zero if FBGEMM runs on this CPU.
+int8_t FBGEMM_IsCPUSupported();
+// QuantizeLinearWeight quantizes the float weight of a linear layer into a
+// QInt8 tensor with the scale and zero point chosen by FBGEMM.
+const char *QuantizeLinearWeight(Tensor weight, Tensor *result);
+// PackLinearWeight prepares the QInt8 weight of a linear layer for
+// DynamicQuantizedLinear.  int_weight is the Char tensor of the integers in
+// weight, packed is opaque, and col_offsets is an Int tensor.
+const char *PackLinearWeight(Tensor weight, Tensor *int_weight, Tensor *packed,
+                             Tensor *col_offsets);
+// PackedWeightKey returns the data pointer and the version counter of
+// weight, which change if weight gets new elements, e.g., by set_data or
+// copy_, and so identify the elements packed by PackLinearWeight.
+void PackedWeightKey(Tensor weight, void **data, int64_t *version);
+// DynamicQuantizedLinear quantizes input on the fly, multiplies it with the
+// packed weight in int8, and adds the float bias, which could be nullptr.
+// scale and zero_point are those of the QInt8 weight.
+const char *DynamicQuantizedLinear(Tensor input, Tensor int_weight,
+                                   Tensor packed, Tensor col_offsets,
+                                   double scale, int64_t zero_point,
+                                   Tensor bias, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
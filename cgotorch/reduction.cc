@@ -0,0 +1,216 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/reduction.h"
+
+const char *SumByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        a->sum(torch::IntArrayRef(dims, dims_len), keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MeanByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                       int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        a->mean(torch::IntArrayRef(dims, dims_len), keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Max(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->max());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MaxByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                     Tensor *indices) {
+  try {
+    auto outputs = a->max(dim, keep_dim != 0);
+    *values = new at::Tensor(std::get<0>(outputs));
+    *indices = new at::Tensor(std::get<1>(outputs));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Min(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->min());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MinByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                     Tensor *indices) {
+  try {
+    auto outputs = a->min(dim, keep_dim != 0);
+    *values = new at::Tensor(std::get<0>(outputs));
+    *indices = new at::Tensor(std::get<1>(outputs));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Var(Tensor a, int8_t unbiased, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->var(unbiased != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *VarByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t unbiased, int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->var(torch::IntArrayRef(dims, dims_len),
+                                    unbiased != 0, keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Std(Tensor a, int8_t unbiased, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->std(unbiased != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *StdByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t unbiased, int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->std(torch::IntArrayRef(dims, dims_len),
+                                    unbiased != 0, keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Prod(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->prod());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *ProdByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->prod(dim, keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Norm(Tensor a, double p, int64_t *dims, int64_t dims_len,
+                 int8_t keep_dim, Tensor *result) {
+  try {
+    if (dims_len == 0) {
+      *result = new at::Tensor(a->norm(p));
+    } else {
+      *result = new at::Tensor(
+          a->norm(p, torch::IntArrayRef(dims, dims_len), keep_dim != 0));
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *FrobeniusNorm(Tensor a, int64_t *dims, int64_t dims_len,
+                          int8_t keep_dim, Tensor *result) {
+  try {
+    if (dims_len == 0) {
+      *result = new at::Tensor(torch::frobenius_norm(*a));
+    } else {
+      *result = new at::Tensor(torch::frobenius_norm(
+          *a, torch::IntArrayRef(dims, dims_len), keep_dim != 0));
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *NuclearNorm(Tensor a, int64_t *dims, int64_t dims_len,
+                        int8_t keep_dim, Tensor *result) {
+  try {
+    if (dims_len == 0) {
+      *result = new at::Tensor(torch::nuclear_norm(*a, keep_dim != 0));
+    } else {
+      *result = new at::Tensor(torch::nuclear_norm(
+          *a, torch::IntArrayRef(dims, dims_len), keep_dim != 0));
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *LogSumExp(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        a->logsumexp(torch::IntArrayRef(dims, dims_len), keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Any(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->any());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *AnyByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->any(dim, keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *All(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->all());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *AllByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->all(dim, keep_dim != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
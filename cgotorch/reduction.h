@@ -0,0 +1,56 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Reductions
+////////////////////////////////////////////////////////////////////////////////
+
+// Reductions over several dimensions take the dimensions as an array dims of
+// length dims_len.
+const char *SumByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t keep_dim, Tensor *result);
+const char *MeanByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                       int8_t keep_dim, Tensor *result);
+
+const char *Max(Tensor a, Tensor *result);
+const char *MaxByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                     Tensor *indices);
+const char *Min(Tensor a, Tensor *result);
+const char *MinByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                     Tensor *indices);
+
+const char *Var(Tensor a, int8_t unbiased, Tensor *result);
+const char *VarByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t unbiased, int8_t keep_dim, Tensor *result);
+const char *Std(Tensor a, int8_t unbiased, Tensor *result);
+const char *StdByDims(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t unbiased, int8_t keep_dim, Tensor *result);
+
+const char *Prod(Tensor a, Tensor *result);
+const char *ProdByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *result);
+
+// Reduce all dimensions if dims_len is 0.
+const char *Norm(Tensor a, double p, int64_t *dims, int64_t dims_len,
+                 int8_t keep_dim, Tensor *result);
+const char *FrobeniusNorm(Tensor a, int64_t *dims, int64_t dims_len,
+                          int8_t keep_dim, Tensor *result);
+const char *NuclearNorm(Tensor a, int64_t *dims, int64_t dims_len,
+                        int8_t keep_dim, Tensor *result);
+
+const char *LogSumExp(Tensor a, int64_t *dims, int64_t dims_len,
+                      int8_t keep_dim, Tensor *result);
+
+const char *Any(Tensor a, Tensor *result);
+const char *AnyByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *result);
+const char *All(Tensor a, Tensor *result);
+const char *AllByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
@@ -0,0 +1,149 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/shape.h"
+
+#include <vector>
+
+const char *Cat(Tensor *tensors, int64_t tensors_size, int64_t dim,
+                Tensor *result) {
+  try {
+    std::vector<torch::Tensor> data;
+    while (data.size() < tensors_size) data.push_back(**tensors++);
+    *result = new at::Tensor(at::cat(data, dim));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Split(Tensor a, int64_t split_size, int64_t dim, Tensor *results,
+                  int64_t results_cap, int64_t *results_len) {
+  try {
+    auto outputs = a->split(split_size, dim);
+    if (static_cast<int64_t>(outputs.size()) > results_cap) {
+      return exception_str("Split: too many outputs");
+    }
+    for (size_t i = 0; i < outputs.size(); i++) {
+      results[i] = new at::Tensor(outputs[i]);
+    }
+    *results_len = outputs.size();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *SplitWithSizes(Tensor a, int64_t *sizes, int64_t sizes_len,
+                           int64_t dim, Tensor *results) {
+  try {
+    auto outputs =
+        a->split_with_sizes(torch::IntArrayRef(sizes, sizes_len), dim);
+    for (size_t i = 0; i < outputs.size(); i++) {
+      results[i] = new at::Tensor(outputs[i]);
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Chunk(Tensor a, int64_t chunks, int64_t dim, Tensor *results,
+                  int64_t *results_len) {
+  try {
+    auto outputs = a->chunk(chunks, dim);
+    for (size_t i = 0; i < outputs.size(); i++) {
+      results[i] = new at::Tensor(outputs[i]);
+    }
+    *results_len = outputs.size();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Unsqueeze(Tensor a, int64_t dim, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->unsqueeze(dim));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Reshape(Tensor a, int64_t *shape, int64_t shape_len,
+                    Tensor *result) {
+  try {
+    *result = new at::Tensor(a->reshape(torch::IntArrayRef(shape, shape_len)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Expand(Tensor a, int64_t *shape, int64_t shape_len,
+                   Tensor *result) {
+  try {
+    *result = new at::Tensor(a->expand(torch::IntArrayRef(shape, shape_len)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Repeat(Tensor a, int64_t *repeats, int64_t repeats_len,
+                   Tensor *result) {
+  try {
+    *result =
+        new at::Tensor(a->repeat(torch::IntArrayRef(repeats, repeats_len)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Flip(Tensor a, int64_t *dims, int64_t dims_len, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->flip(torch::IntArrayRef(dims, dims_len)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Roll(Tensor a, int64_t *shifts, int64_t shifts_len, int64_t *dims,
+                 int64_t dims_len, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->roll(torch::IntArrayRef(shifts, shifts_len),
+                                     torch::IntArrayRef(dims, dims_len)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Contiguous(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->contiguous());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_IsContiguous(Tensor a, int8_t *result) {
+  try {
+    *result = a->is_contiguous() ? 1 : 0;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Strides(Tensor a, int64_t *strides) {
+  try {
+    int i = 0;
+    for (int64_t stride : a->strides()) strides[i++] = stride;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,43 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Shape manipulation
+////////////////////////////////////////////////////////////////////////////////
+
+const char *Cat(Tensor *tensors, int64_t tensors_size, int64_t dim,
+                Tensor *result);
+
+// Split and Chunk write at most results_cap tensors into results, and the
+// number of them into results_len.
+const char *Split(Tensor a, int64_t split_size, int64_t dim, Tensor *results,
+                  int64_t results_cap, int64_t *results_len);
+const char *SplitWithSizes(Tensor a, int64_t *sizes, int64_t sizes_len,
+                           int64_t dim, Tensor *results);
+const char *Chunk(Tensor a, int64_t chunks, int64_t dim, Tensor *results,
+                  int64_t *results_len);
+
+const char *Unsqueeze(Tensor a, int64_t dim, Tensor *result);
+const char *Reshape(Tensor a, int64_t *shape, int64_t shape_len,
+                    Tensor *result);
+const char *Expand(Tensor a, int64_t *shape, int64_t shape_len,
+                   Tensor *result);
+const char *Repeat(Tensor a, int64_t *repeats, int64_t repeats_len,
+                   Tensor *result);
+const char *Flip(Tensor a, int64_t *dims, int64_t dims_len, Tensor *result);
+const char *Roll(Tensor a, int64_t *shifts, int64_t shifts_len, int64_t *dims,
+                 int64_t dims_len, Tensor *result);
+const char *Contiguous(Tensor a, Tensor *result);
+
+const char *Tensor_IsContiguous(Tensor a, int8_t *result);
+const char *Tensor_Strides(Tensor a, int64_t *strides);
+
+#ifdef __cplusplus
+}
+#endif
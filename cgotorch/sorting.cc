@@ -0,0 +1,298 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/sorting.h"
+
+#include <algorithm>
+#include <numeric>
+#include <stdexcept>
+#include <string>
+
+namespace {
+
+template <typename T>
+bool IsNaN(T x) {
+  return x != x;
+}
+
+// NaN is larger than any number, as in torch.sort.
+template <typename T>
+bool Less(T x, T y) {
+  return !IsNaN(x) && (IsNaN(y) || x < y);
+}
+
+// StableArgsort returns the indices that sort a along dim by std::stable_sort.
+at::Tensor StableArgsort(const at::Tensor &a, int64_t dim, bool descending) {
+  if (a.dim() == 0) {
+    return torch::zeros({}, a.options().dtype(torch::kLong));
+  }
+  dim = at::maybe_wrap_dim(dim, a.dim());
+  auto t = a.detach().to(torch::kCPU).transpose(dim, -1).contiguous();
+  auto idx = torch::empty(t.sizes(), torch::kLong);
+  int64_t n = t.size(-1);
+  int64_t rows = n == 0 ? 0 : t.numel() / n;
+  int64_t *p = idx.data_ptr<int64_t>();
+  AT_DISPATCH_ALL_TYPES_AND2(
+      at::ScalarType::Half, at::ScalarType::Bool, t.scalar_type(),
+      "StableArgsort", [&] {
+        const scalar_t *v = t.data_ptr<scalar_t>();
+        for (int64_t r = 0; r < rows; ++r) {
+          int64_t *row = p + r * n;
+          const scalar_t *vr = v + r * n;
+          std::iota(row, row + n, 0);
+          std::stable_sort(row, row + n,
+                           [vr, descending](int64_t i, int64_t j) {
+                             return descending ? Less(vr[j], vr[i])
+                                               : Less(vr[i], vr[j]);
+                           });
+        }
+      });
+  return idx.transpose(dim, -1).contiguous().to(a.device());
+}
+
+// ScatterReduceOnCPU scatters src into a copy of a by the binary function f,
+// which takes and returns values of the element type of a.
+template <typename F>
+at::Tensor ScatterReduceOnCPU(const at::Tensor &a, int64_t dim,
+                              const at::Tensor &index, const at::Tensor &src,
+                              F f) {
+  dim = at::maybe_wrap_dim(dim, a.dim());
+  if (index.dim() != a.dim() || src.dim() != a.dim()) {
+    throw std::invalid_argument(
+        "ScatterReduce: index and src must have as many dimensions as self");
+  }
+  for (int64_t d = 0; d < index.dim(); ++d) {
+    if (index.size(d) > src.size(d) ||
+        (d != dim && index.size(d) > a.size(d))) {
+      throw std::invalid_argument(
+          "ScatterReduce: index is larger than self or src in dimension " +
+          std::to_string(d));
+    }
+  }
+  auto out = a.detach().to(torch::kCPU).contiguous().clone();
+  auto idx = index.to(torch::kCPU, torch::kLong).contiguous();
+  auto s = src.detach().to(torch::kCPU, a.scalar_type());
+  for (int64_t d = 0; d < idx.dim(); ++d) s = s.narrow(d, 0, idx.size(d));
+  s = s.contiguous();
+
+  auto sizes = idx.sizes();
+  auto strides = out.strides();
+  const int64_t *ip = idx.data_ptr<int64_t>();
+  AT_DISPATCH_ALL_TYPES_AND(
+      at::ScalarType::Half, out.scalar_type(), "ScatterReduce", [&] {
+        scalar_t *o = out.data_ptr<scalar_t>();
+        const scalar_t *sp = s.data_ptr<scalar_t>();
+        for (int64_t k = 0; k < idx.numel(); ++k) {
+          if (ip[k] < 0 || ip[k] >= out.size(dim)) {
+            throw std::invalid_argument("ScatterReduce: index " +
+                                        std::to_string(ip[k]) +
+                                        " out of range");
+          }
+          int64_t rem = k, offset = 0;
+          for (int64_t d = idx.dim() - 1; d >= 0; --d) {
+            int64_t c = rem % sizes[d];
+            rem /= sizes[d];
+            offset += (d == dim ? ip[k] : c) * strides[d];
+          }
+          o[offset] = static_cast<scalar_t>(f(o[offset], sp[k]));
+        }
+      });
+  return out.to(a.device());
+}
+
+}  // namespace
+
+const char *Sort(Tensor a, int64_t dim, int8_t descending, int8_t stable,
+                 Tensor *values, Tensor *indices) {
+  try {
+    if (stable != 0) {
+      auto i = StableArgsort(*a, dim, descending != 0);
+      *values = new at::Tensor(a->dim() == 0 ? *a : a->gather(dim, i));
+      *indices = new at::Tensor(i);
+      return nullptr;
+    }
+    auto r = a->sort(dim, descending != 0);
+    *values = new at::Tensor(std::get<0>(r));
+    *indices = new at::Tensor(std::get<1>(r));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Argsort(Tensor a, int64_t dim, int8_t descending, int8_t stable,
+                    Tensor *result) {
+  try {
+    if (stable != 0) {
+      *result = new at::Tensor(StableArgsort(*a, dim, descending != 0));
+    } else {
+      *result = new at::Tensor(a->argsort(dim, descending != 0));
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Unique(Tensor a, int8_t sorted, Tensor *values, Tensor *inverse,
+                   Tensor *counts) {
+  try {
+    auto r = at::_unique2(*a, sorted != 0, true, true);
+    *values = new at::Tensor(std::get<0>(r));
+    *inverse = new at::Tensor(std::get<1>(r));
+    *counts = new at::Tensor(std::get<2>(r));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Gather(Tensor a, int64_t dim, Tensor index, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->gather(dim, *index));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Scatter(Tensor a, int64_t dim, Tensor index, Tensor src,
+                    Tensor *result) {
+  try {
+    *result = new at::Tensor(a->scatter(dim, *index, *src));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *ScatterValue(Tensor a, int64_t dim, Tensor index, double value,
+                         Tensor *result) {
+  try {
+    *result = new at::Tensor(a->scatter(dim, *index, value));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *ScatterAdd(Tensor a, int64_t dim, Tensor index, Tensor src,
+                       Tensor *result) {
+  try {
+    *result = new at::Tensor(a->scatter_add(dim, *index, *src));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// libtorch 1.6 doesn't have scatter_reduce.  "sum" and "mean" use scatter_add
+// and support autograd, the others run on CPU and don't.
+const char *ScatterReduce(Tensor a, int64_t dim, Tensor index, Tensor src,
+                          const char *reduce, Tensor *result) {
+  try {
+    std::string r(reduce);
+    at::Tensor t;
+    if (r == "sum") {
+      t = a->scatter_add(dim, *index, *src);
+    } else if (r == "mean") {
+      auto sum = a->scatter_add(dim, *index, *src);
+      auto count = torch::ones_like(*a).scatter_add(
+          dim, *index, torch::ones(index->sizes(), a->options()));
+      t = at::isIntegralType(a->scalar_type(), true) ? sum.floor_divide(count)
+                                                     : sum / count;
+    } else if (r == "prod") {
+      t = ScatterReduceOnCPU(*a, dim, *index, *src,
+                             [](auto x, auto y) { return x * y; });
+    } else if (r == "amax") {
+      t = ScatterReduceOnCPU(*a, dim, *index, *src, [](auto x, auto y) {
+        return IsNaN(x) || (!IsNaN(y) && x > y) ? x : y;
+      });
+    } else if (r == "amin") {
+      t = ScatterReduceOnCPU(*a, dim, *index, *src, [](auto x, auto y) {
+        return IsNaN(x) || (!IsNaN(y) && x < y) ? x : y;
+      });
+    } else {
+      return exception_str(("ScatterReduce: unknown reduce " + r).c_str());
+    }
+    *result = new at::Tensor(t);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *IndexAdd(Tensor a, int64_t dim, Tensor index, Tensor src,
+                     Tensor *result) {
+  try {
+    *result = new at::Tensor(a->index_add(dim, *index, *src));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Searchsorted(Tensor sorted_sequence, Tensor values, int8_t right,
+                         Tensor *result) {
+  try {
+    *result = new at::Tensor(
+        torch::searchsorted(*sorted_sequence, *values, false, right != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Bucketize(Tensor a, Tensor boundaries, int8_t right,
+                      Tensor *result) {
+  try {
+    *result =
+        new at::Tensor(torch::bucketize(*a, *boundaries, false, right != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Kthvalue(Tensor a, int64_t k, int64_t dim, int8_t keep_dim,
+                     Tensor *values, Tensor *indices) {
+  try {
+    auto r = a->kthvalue(k, dim, keep_dim != 0);
+    *values = new at::Tensor(std::get<0>(r));
+    *indices = new at::Tensor(std::get<1>(r));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Median(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->median());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *MedianByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                        Tensor *indices) {
+  try {
+    auto r = a->median(dim, keep_dim != 0);
+    *values = new at::Tensor(std::get<0>(r));
+    *indices = new at::Tensor(std::get<1>(r));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Mode(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                 Tensor *indices) {
+  try {
+    auto r = a->mode(dim, keep_dim != 0);
+    *values = new at::Tensor(std::get<0>(r));
+    *indices = new at::Tensor(std::get<1>(r));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,52 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Sorting, gather/scatter and searching
+////////////////////////////////////////////////////////////////////////////////
+
+// libtorch 1.6 doesn't support stable sorting, so Sort and Argsort sort on CPU
+// by std::stable_sort if stable is non-zero.
+const char *Sort(Tensor a, int64_t dim, int8_t descending, int8_t stable,
+                 Tensor *values, Tensor *indices);
+const char *Argsort(Tensor a, int64_t dim, int8_t descending, int8_t stable,
+                    Tensor *result);
+const char *Unique(Tensor a, int8_t sorted, Tensor *values, Tensor *inverse,
+                   Tensor *counts);
+
+const char *Gather(Tensor a, int64_t dim, Tensor index, Tensor *result);
+const char *Scatter(Tensor a, int64_t dim, Tensor index, Tensor src,
+                    Tensor *result);
+const char *ScatterValue(Tensor a, int64_t dim, Tensor index, double value,
+                         Tensor *result);
+const char *ScatterAdd(Tensor a, int64_t dim, Tensor index, Tensor src,
+                       Tensor *result);
+// reduce is one of "sum", "prod", "mean", "amax" and "amin".  The reduction
+// includes the elements of a.
+const char *ScatterReduce(Tensor a, int64_t dim, Tensor index, Tensor src,
+                          const char *reduce, Tensor *result);
+const char *IndexAdd(Tensor a, int64_t dim, Tensor index, Tensor src,
+                     Tensor *result);
+
+const char *Searchsorted(Tensor sorted_sequence, Tensor values, int8_t right,
+                         Tensor *result);
+const char *Bucketize(Tensor a, Tensor boundaries, int8_t right,
+                      Tensor *result);
+
+const char *Kthvalue(Tensor a, int64_t k, int64_t dim, int8_t keep_dim,
+                     Tensor *values, Tensor *indices);
+const char *Median(Tensor a, Tensor *result);
+const char *MedianByDim(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                        Tensor *indices);
+const char *Mode(Tensor a, int64_t dim, int8_t keep_dim, Tensor *values,
+                 Tensor *indices);
+
+#ifdef __cplusplus
+}
+#endif
@@ -0,0 +1,117 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/sparse.h"
+
+#include <vector>
+
+const char *SparseCOO(Tensor indices, Tensor values, int64_t *shape,
+                      int64_t shape_len, Tensor *result) {
+  try {
+    if (shape == nullptr) {
+      *result = new at::Tensor(at::sparse_coo_tensor(*indices, *values));
+    } else {
+      *result = new at::Tensor(at::sparse_coo_tensor(
+          *indices, *values, at::IntArrayRef(shape, shape_len)));
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_IsSparse(Tensor a, int8_t *result) {
+  try {
+    *result = a->is_sparse() ? 1 : 0;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_ToDense(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->is_sparse() ? a->to_dense() : *a);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_ToSparse(Tensor a, int64_t sparse_dims, Tensor *result) {
+  try {
+    *result = new at::Tensor(sparse_dims == -1 ? a->to_sparse()
+                                               : a->to_sparse(sparse_dims));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Coalesce(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->coalesce());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_IsCoalesced(Tensor a, int8_t *result) {
+  try {
+    *result = a->is_coalesced() ? 1 : 0;
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Indices(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->indices());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_Values(Tensor a, Tensor *result) {
+  try {
+    *result = new at::Tensor(a->values());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Tensor_NNZ(Tensor a, int64_t *result) {
+  try {
+    *result = a->_nnz();
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *SparseSum(Tensor a, int64_t *dims, int64_t dims_len,
+                      Tensor *result) {
+  try {
+    if (dims_len == 0) {
+      *result = new at::Tensor(at::_sparse_sum(*a));
+    } else {
+      *result =
+          new at::Tensor(at::_sparse_sum(*a, at::IntArrayRef(dims, dims_len)));
+    }
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *Addmm(Tensor input, Tensor mat1, Tensor mat2, double beta,
+                  double alpha, Tensor *result) {
+  try {
+    *result = new at::Tensor(at::addmm(*input, *mat1, *mat2, beta, alpha));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,38 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Sparse COO tensors
+////////////////////////////////////////////////////////////////////////////////
+
+// SparseCOO infers the shape from indices if shape is nullptr.
+const char *SparseCOO(Tensor indices, Tensor values, int64_t *shape,
+                      int64_t shape_len, Tensor *result);
+const char *Tensor_IsSparse(Tensor a, int8_t *result);
+const char *Tensor_ToDense(Tensor a, Tensor *result);
+// Tensor_ToSparse converts the first sparse_dims dimensions, or all if
+// sparse_dims is -1, into sparse ones.
+const char *Tensor_ToSparse(Tensor a, int64_t sparse_dims, Tensor *result);
+const char *Tensor_Coalesce(Tensor a, Tensor *result);
+const char *Tensor_IsCoalesced(Tensor a, int8_t *result);
+const char *Tensor_Indices(Tensor a, Tensor *result);
+const char *Tensor_Values(Tensor a, Tensor *result);
+const char *Tensor_NNZ(Tensor a, int64_t *result);
+
+// SparseSum sums over dims, or all dimensions if dims_len is 0.
+const char *SparseSum(Tensor a, int64_t *dims, int64_t dims_len,
+                      Tensor *result);
+// Addmm computes beta * input + alpha * (mat1 @ mat2), where mat1 could be
+// sparse.
+const char *Addmm(Tensor input, Tensor mat1, Tensor mat2, double beta,
+                  double alpha, Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
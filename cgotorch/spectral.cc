@@ -0,0 +1,244 @@
+// Copyright 2020, GoTorch Authors
+#include "cgotorch/spectral.h"
+
+#include <cmath>
+#include <stdexcept>
+#include <string>
+#include <vector>
+
+namespace {
+
+// libtorch 1.6 provides the old torch.fft API, which transforms real tensors
+// whose last dimension of size 2 holds the real and imaginary parts.  We
+// implement the torch.fft module of later versions with this representation,
+// denoted by "real pairs" below.
+
+// AsRealPairs returns the real pairs of a complex or real tensor.
+at::Tensor AsRealPairs(const at::Tensor &a) {
+  if (a.is_complex()) {
+    return at::view_as_real(a);
+  }
+  auto x = at::isFloatingType(a.scalar_type()) ? a : a.to(torch::kFloat);
+  return torch::stack({x, torch::zeros_like(x)}, -1);
+}
+
+at::Tensor AsComplex(const at::Tensor &r) {
+  return at::view_as_complex(r.contiguous());
+}
+
+// Resize trims or zero-pads dimension dim of the real pairs r to n.
+at::Tensor Resize(const at::Tensor &r, int64_t dim, int64_t n) {
+  int64_t m = r.size(dim);
+  if (n < m) {
+    return r.narrow(dim, 0, n);
+  }
+  if (n > m) {
+    auto shape = r.sizes().vec();
+    shape[dim] = n - m;
+    return torch::cat({r, torch::zeros(shape, r.options())}, dim);
+  }
+  return r;
+}
+
+// Scale returns the factor that turns the result of at::fft or at::ifft, which
+// use the "backward" normalization, into that of norm.
+double Scale(const std::string &norm, int64_t n, bool inverse) {
+  if (norm == "backward") {
+    return 1;
+  } else if (norm == "ortho") {
+    return inverse ? std::sqrt(n) : 1 / std::sqrt(n);
+  } else if (norm == "forward") {
+    return inverse ? n : 1.0 / n;
+  }
+  throw std::invalid_argument("unknown norm " + norm);
+}
+
+// FFT1 transforms dimension dim of the real pairs r with n points.
+at::Tensor FFT1(const at::Tensor &r, int64_t dim, int64_t n,
+                const std::string &norm, bool inverse) {
+  if (n <= 0) {
+    throw std::invalid_argument("invalid number of points " +
+                                std::to_string(n));
+  }
+  auto x = Resize(r, dim, n).transpose(dim, -2).contiguous();
+  auto y = inverse ? at::ifft(x, 1) : at::fft(x, 1);
+  double s = Scale(norm, n, inverse);
+  if (s != 1) {
+    y = y * s;
+  }
+  return y.transpose(dim, -2);
+}
+
+// Roll rolls a complex or real tensor.  dims must be non-negative.
+at::Tensor Roll(const at::Tensor &a, const std::vector<int64_t> &shifts,
+                const std::vector<int64_t> &dims) {
+  if (a.is_complex()) {
+    return AsComplex(at::view_as_real(a).roll(shifts, dims));
+  }
+  return a.roll(shifts, dims);
+}
+
+}  // namespace
+
+const char *FFTN(Tensor a, int64_t *s, int64_t s_len, int64_t *dims,
+                 int64_t dims_len, const char *norm, int8_t inverse,
+                 Tensor *result) {
+  try {
+    std::vector<int64_t> d(dims, dims + dims_len);
+    if (d.empty()) {
+      // Transform the last s_len dimensions, or all if s is not specified.
+      int64_t n = s_len > 0 ? s_len : a->dim();
+      for (int64_t i = a->dim() - n; i < a->dim(); ++i) {
+        d.push_back(i);
+      }
+    }
+    if (s_len > 0 && s_len != static_cast<int64_t>(d.size())) {
+      throw std::invalid_argument("FFTN: s and dims have different lengths");
+    }
+    auto r = AsRealPairs(*a);
+    for (size_t i = 0; i < d.size(); ++i) {
+      int64_t dim = at::maybe_wrap_dim(d[i], a->dim());
+      int64_t n = s_len > 0 && s[i] != -1 ? s[i] : r.size(dim);
+      r = FFT1(r, dim, n, norm, inverse != 0);
+    }
+    *result = new at::Tensor(AsComplex(r));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *RFFT(Tensor a, int64_t n, int64_t dim, const char *norm,
+                 Tensor *result) {
+  try {
+    if (a->is_complex()) {
+      throw std::invalid_argument("RFFT expects a real input tensor");
+    }
+    dim = at::maybe_wrap_dim(dim, a->dim());
+    if (n == -1) {
+      n = a->size(dim);
+    }
+    auto r = FFT1(AsRealPairs(*a), dim, n, norm, false);
+    *result = new at::Tensor(AsComplex(r.narrow(dim, 0, n / 2 + 1)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// IRFFT restores the full Hermitian spectrum, X[k] = conj(X[n-k]), before the
+// inverse transform.
+const char *IRFFT(Tensor a, int64_t n, int64_t dim, const char *norm,
+                  Tensor *result) {
+  try {
+    dim = at::maybe_wrap_dim(dim, a->dim());
+    if (n == -1) {
+      n = 2 * (a->size(dim) - 1);
+    }
+    if (n <= 0) {
+      throw std::invalid_argument("IRFFT: invalid number of points " +
+                                  std::to_string(n));
+    }
+    int64_t half = n / 2 + 1;
+    auto r = Resize(AsRealPairs(*a), dim, half);
+    auto conj = torch::tensor({1.0, -1.0}, r.options());
+    auto tail = r.narrow(dim, 1, n - half).flip({dim}) * conj;
+    auto y = FFT1(torch::cat({r, tail}, dim), dim, n, norm, true);
+    *result = new at::Tensor(y.select(-1, 0).contiguous());
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *FFTShift(Tensor a, int64_t *dims, int64_t dims_len, int8_t inverse,
+                     Tensor *result) {
+  try {
+    std::vector<int64_t> d, shifts;
+    for (int64_t i = 0; i < (dims_len > 0 ? dims_len : a->dim()); ++i) {
+      d.push_back(at::maybe_wrap_dim(dims_len > 0 ? dims[i] : i, a->dim()));
+      int64_t n = a->size(d.back());
+      shifts.push_back(inverse ? -(n / 2) : n / 2);
+    }
+    *result = new at::Tensor(d.empty() ? *a : Roll(*a, shifts, d));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+// at::stft doesn't pad the input, which torch.stft does in Python if center.
+const char *STFT(Tensor a, int64_t n_fft, int64_t hop_length,
+                 int64_t win_length, Tensor window, int8_t center,
+                 const char *pad_mode, int8_t normalized, int8_t onesided,
+                 Tensor *result) {
+  try {
+    auto x = *a;
+    if (center) {
+      int64_t pad = n_fft / 2;
+      auto shape = x.sizes().vec();
+      x = x.reshape({-1, 1, x.size(-1)});
+      std::string mode(pad_mode);
+      if (mode == "reflect") {
+        x = at::reflection_pad1d(x, {pad, pad});
+      } else if (mode == "replicate") {
+        x = at::replication_pad1d(x, {pad, pad});
+      } else if (mode == "constant") {
+        x = at::constant_pad_nd(x, {pad, pad});
+      } else {
+        throw std::invalid_argument("STFT: unknown pad_mode " + mode);
+      }
+      shape.back() = x.size(-1);
+      x = x.reshape(shape);
+    }
+    auto r = at::stft(
+        x, n_fft,
+        hop_length == -1 ? c10::nullopt : c10::optional<int64_t>(hop_length),
+        win_length == -1 ? c10::nullopt : c10::optional<int64_t>(win_length),
+        window == nullptr ? at::Tensor() : *window, normalized != 0,
+        onesided != 0);
+    *result = new at::Tensor(AsComplex(r));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *ISTFT(Tensor a, int64_t n_fft, int64_t hop_length,
+                  int64_t win_length, Tensor window, int8_t center,
+                  int8_t normalized, int8_t onesided, int64_t length,
+                  Tensor *result) {
+  try {
+    auto r = at::istft(
+        AsRealPairs(*a), n_fft,
+        hop_length == -1 ? c10::nullopt : c10::optional<int64_t>(hop_length),
+        win_length == -1 ? c10::nullopt : c10::optional<int64_t>(win_length),
+        window == nullptr ? at::Tensor() : *window, center != 0,
+        normalized != 0, onesided != 0,
+        length == -1 ? c10::nullopt : c10::optional<int64_t>(length));
+    *result = new at::Tensor(r);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *HannWindow(int64_t window_length, int8_t periodic, Tensor *result) {
+  try {
+    *result = new at::Tensor(torch::hann_window(window_length, periodic != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
+const char *HammingWindow(int64_t window_length, int8_t periodic,
+                          Tensor *result) {
+  try {
+    *result =
+        new at::Tensor(torch::hamming_window(window_length, periodic != 0));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
@@ -0,0 +1,46 @@
+/* Copyright 2020, GoTorch Authors */
+#pragma once
+
+#include "cgotorch/torchdef.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+////////////////////////////////////////////////////////////////////////////////
+// Spectral operations, torch.fft and torch.stft
+////////////////////////////////////////////////////////////////////////////////
+
+// FFTN transforms a along dims, or all dimensions if dims_len is 0, with s[i]
+// points along dims[i].  s is nullptr or has dims_len elements, where -1 means
+// the size of the dimension.  norm is "backward", "ortho" or "forward".  The
+// result is complex.
+const char *FFTN(Tensor a, int64_t *s, int64_t s_len, int64_t *dims,
+                 int64_t dims_len, const char *norm, int8_t inverse,
+                 Tensor *result);
+// n is -1 for the default number of points.
+const char *RFFT(Tensor a, int64_t n, int64_t dim, const char *norm,
+                 Tensor *result);
+const char *IRFFT(Tensor a, int64_t n, int64_t dim, const char *norm,
+                  Tensor *result);
+const char *FFTShift(Tensor a, int64_t *dims, int64_t dims_len, int8_t inverse,
+                     Tensor *result);
+
+// hop_length and win_length are -1 for the defaults.  window and length are
+// nullptr and -1 if not specified.
+const char *STFT(Tensor a, int64_t n_fft, int64_t hop_length,
+                 int64_t win_length, Tensor window, int8_t center,
+                 const char *pad_mode, int8_t normalized, int8_t onesided,
+                 Tensor *result);
+const char *ISTFT(Tensor a, int64_t n_fft, int64_t hop_length,
+                  int64_t win_length, Tensor window, int8_t center,
+                  int8_t normalized, int8_t onesided, int64_t length,
+                  Tensor *result);
+
+const char *HannWindow(int64_t window_length, int8_t periodic, Tensor *result);
+const char *HammingWindow(int64_t window_length, int8_t periodic,
+                          Tensor *result);
+
+#ifdef __cplusplus
+}
+#endif
@@ -1,6 +1,7 @@
 // Copyright 2020, GoTorch Authors
 #include "cgotorch/tensor.h"
 
+#include <cstring>
 #include <string>
 #include <vector>
 
@@ -101,6 +102,16 @@ const char *Tensor_Dtype(Tensor tensor, int8_t *dtype) {
   }
 }
 
+const char *PromoteTypes(int8_t a, int8_t b, int8_t *result) {
+  try {
+    *result = static_cast<int8_t>(c10::promoteTypes(
+        static_cast<at::ScalarType>(a), static_cast<at::ScalarType>(b)));
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
+
 // The caller must free the returned string by calling FreeString.
 const char *Tensor_String(Tensor a) {
   std::stringstream ss;
@@ -171,6 +182,7 @@ const char *Tensor_PinMemory(Tensor input, Tensor *output) {
 // Backward, Gradient
 void Tensor_Backward(Tensor a) { a->backward(); }
 Tensor Tensor_Grad(Tensor a) { return new at::Tensor(a->grad()); }
+int8_t Tensor_Defined(Tensor a) { return a->defined() ? 1 : 0; }
 
 const char *Tensor_SetData(Tensor self, Tensor new_data) {
   try {
@@ -211,3 +223,23 @@ const char *Tensor_Index(Tensor input, int64_t *index, int64_t index_len,
     return exception_str(e.what());
   }
 }
+
+// Tensor_CopyData moves a to CPU, casts it to dtype, and makes it contiguous
+// and dense if necessary, before copying the elements into data.
+const char *Tensor_CopyData(Tensor a, int8_t dtype, void *data,
+                            int64_t nbytes) {
+  try {
+    auto t = a->detach();
+    if (t.is_sparse()) {
+      t = t.to_dense();
+    }
+    t = t.to(torch::kCPU, static_cast<at::ScalarType>(dtype)).contiguous();
+    if (static_cast<int64_t>(t.nbytes()) != nbytes) {
+      return exception_str("Tensor_CopyData: buffer size mismatches");
+    }
+    memcpy(data, t.data_ptr(), nbytes);
+    return nullptr;
+  } catch (const std::exception &e) {
+    return exception_str(e.what());
+  }
+}
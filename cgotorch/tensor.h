@@ -21,6 +21,7 @@ const char *Tensor_Load(const char *path, Tensor *result);
 const char *Tensor_Dim(Tensor tensor, int64_t *dim);
 const char *Tensor_Shape(Tensor tensor, int64_t *dims);
 const char *Tensor_Dtype(Tensor tensor, int8_t *dtype);
+const char *PromoteTypes(int8_t a, int8_t b, int8_t *result);
 const char *Tensor_SetData(Tensor self, Tensor new_data);
 const char *Tensor_FromBlob(void *data, int8_t dtype, int64_t *sizes_data,
                             int64_t sizes_data_len, Tensor *result);
@@ -38,6 +39,7 @@ const char *Tensor_CUDA(Tensor input, Device device, int8_t non_blocking,
 
 void Tensor_Backward(Tensor a);
 Tensor Tensor_Grad(Tensor a);
+int8_t Tensor_Defined(Tensor a);
 
 ////////////////////////////////////////////////////////////////////////////////
 // Get elements
@@ -50,6 +52,9 @@ const char *ItemFloat64(Tensor a, double *result);
 const char *Tensor_Index(Tensor a, int64_t *index, int64_t index_len,
                          Tensor *result);
 
+// Copy the elements, casted to dtype, into data, which has nbytes bytes.
+const char *Tensor_CopyData(Tensor a, int8_t dtype, void *data, int64_t nbytes);
+
 #ifdef __cplusplus
 }
 #endif
@@ -1,6 +1,9 @@
 // Copyright 2020, GoTorch Authors
 #include "cgotorch/torch.h"
 
+#include "cgotorch/generator.h"
+#include "cgotorch/inplace.h"
+
 #include <vector>
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -19,10 +22,10 @@ const char *exception_str(const char *e) {
 ////////////////////////////////////////////////////////////////////////////////
 
 const char *RandN(int64_t *size, int64_t length, int64_t requires_grad,
-                  Tensor *result) {
+                  Generator gen, Tensor *result) {
   try {
     at::Tensor t =
-        torch::randn(torch::IntArrayRef(size, length),
+        torch::randn(torch::IntArrayRef(size, length), OptionalGenerator(gen),
                      at::TensorOptions().requires_grad(requires_grad));
     *result = new at::Tensor(t);
     return nullptr;
@@ -32,10 +35,10 @@ const char *RandN(int64_t *size, int64_t length, int64_t requires_grad,
 }
 
 const char *Rand(int64_t *size, int64_t length, int64_t requires_grad,
-                 Tensor *result) {
+                 Generator gen, Tensor *result) {
   try {
     at::Tensor t =
-        torch::rand(torch::IntArrayRef(size, length),
+        torch::rand(torch::IntArrayRef(size, length), OptionalGenerator(gen),
                     at::TensorOptions().requires_grad(requires_grad));
     *result = new at::Tensor(t);
     return nullptr;
@@ -234,7 +237,7 @@ const char *Add(Tensor a, Tensor other, float alpha, Tensor *result) {
 
 const char *Add_(Tensor a, Tensor other, float alpha, Tensor *result) {
   try {
-    *result = new at::Tensor(a->add_(*other, alpha));
+    *result = new at::Tensor(CheckMutable(*a).add_(*other, alpha));
     return nullptr;
   } catch (const std::exception &e) {
     return exception_str(e.what());
@@ -252,7 +255,7 @@ const char *Sub(Tensor a, Tensor other, float alpha, Tensor *result) {
 
 const char *Sub_(Tensor a, Tensor other, float alpha, Tensor *result) {
   try {
-    *result = new at::Tensor(a->sub_(*other, alpha));
+    *result = new at::Tensor(CheckMutable(*a).sub_(*other, alpha));
     return nullptr;
   } catch (const std::exception &e) {
     return exception_str(e.what());
@@ -270,7 +273,7 @@ const char *Mul(Tensor a, Tensor other, Tensor *result) {
 
 const char *Mul_(Tensor a, Tensor other, Tensor *result) {
   try {
-    *result = new at::Tensor(a->mul_(*other));
+    *result = new at::Tensor(CheckMutable(*a).mul_(*other));
     return nullptr;
   } catch (const std::exception &e) {
     return exception_str(e.what());
@@ -288,7 +291,7 @@ const char *Div(Tensor a, Tensor other, Tensor *result) {
 
 const char *Div_(Tensor a, Tensor other, Tensor *result) {
   try {
-    *result = new at::Tensor(a->div_(*other));
+    *result = new at::Tensor(CheckMutable(*a).div_(*other));
     return nullptr;
   } catch (const std::exception &e) {
     return exception_str(e.what());
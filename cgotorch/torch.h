@@ -11,12 +11,12 @@ extern "C" {
 // Tensor construction and operations, torch
 ////////////////////////////////////////////////////////////////////////////////
 
-// torch.randn
+// torch.randn, gen could be nullptr for the default generator
 const char *RandN(int64_t *size, int64_t length, int64_t require_grad,
-                  Tensor *result);
-// torch.rand
+                  Generator gen, Tensor *result);
+// torch.rand, gen could be nullptr for the default generator
 const char *Rand(int64_t *size, int64_t length, int64_t require_grad,
-                 Tensor *result);
+                 Generator gen, Tensor *result);
 // torch.empty
 const char *Empty(int64_t *size, int64_t length, int64_t require_grad,
                   Tensor *result);
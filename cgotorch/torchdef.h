@@ -14,6 +14,7 @@ typedef torch::optim::Optimizer *Optimizer;
 typedef torch::data::datasets::MNIST *MNIST;
 typedef torch::data::transforms::Normalize<> *Normalize;
 typedef torch::Device *Device;
+typedef at::Generator *Generator;
 typedef std::vector<char> *ByteBuffer;  // NOLINT
 #else
 typedef void *Tensor;
@@ -21,6 +22,7 @@ typedef void *Optimizer;
 typedef void *MNIST;
 typedef void *Normalize;
 typedef void *Device;
+typedef void *Generator;
 typedef void *ByteBuffer;
 #endif
 typedef void *CUDAStream;
@@ -0,0 +1,119 @@
+package gotorch_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestGradModePerGoroutine(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 2}, map[string]interface{}{"requires_grad": true})
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < 50; k++ {
+				if i%2 == 0 {
+					torch.NoGrad(func() {
+						runtime.Gosched()
+						a.False(x.Mul(x).RequiresGrad())
+					})
+				} else {
+					runtime.Gosched()
+					a.True(x.Mul(x).RequiresGrad())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSetGradEnabledInGoroutine(t *testing.T) {
+	a := assert.New(t)
+	done := make(chan bool)
+	go func() {
+		torch.SetGradEnabled(false)
+		runtime.Gosched()
+		a.False(torch.IsGradEnabled())
+		torch.SetGradEnabled(false)
+		// Exit without enabling again.  Go terminates the locked thread.
+		done <- true
+	}()
+	<-done
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.True(torch.IsGradEnabled())
+			torch.SetGradEnabled(false)
+			torch.NoGrad(func() {})
+			a.False(torch.IsGradEnabled())
+			torch.SetGradEnabled(true)
+			torch.SetGradEnabled(true)
+			a.True(torch.IsGradEnabled())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSetGradEnabledInNoGrad(t *testing.T) {
+	a := assert.New(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < 20; k++ {
+				if i%2 == 1 {
+					runtime.Gosched()
+					a.True(torch.IsGradEnabled())
+					continue
+				}
+				torch.SetGradEnabled(false)
+				torch.NoGrad(func() {
+					torch.SetGradEnabled(true)
+					runtime.Gosched()
+					a.True(torch.IsGradEnabled())
+				})
+				// The guard restores the disabled mode, which must stay with
+				// this goroutine.
+				for j := 0; j < 5; j++ {
+					runtime.Gosched()
+					a.False(torch.IsGradEnabled())
+				}
+				torch.SetGradEnabled(true)
+				a.True(torch.IsGradEnabled())
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentOperationsDontLeak(t *testing.T) {
+	a := assert.New(t)
+	w := torch.RandN([]int64{8, 8}, false)
+	expected := torch.MM(w, w).Float32s()
+	before := torch.MemoryStats().LiveTensors
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 20; k++ {
+				torch.Scope(func(s *torch.TensorScope) {
+					a.Equal(expected, torch.MM(w, w).Float32s())
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	a.True(torch.MemoryStats().LiveTensors <= before)
+}
@@ -3,24 +3,177 @@ package gotorch
 // #cgo CFLAGS: -I ${SRCDIR}
 // #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
 // #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include <stdlib.h>
 // #include "cgotorch/cgotorch.h"
 import "C"
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"runtime"
+	"sync"
 	"unsafe"
 )
 
-// Device wrapper a pointer to C.Device
+// Device wrapper a pointer to C.Device.  Devices with the same type and index
+// share the same C.Device, so they are comparable by ==.
 type Device struct {
 	T C.Device
 }
 
-// NewDevice returns a Device
+type deviceKey struct {
+	typ, index int8
+}
+
+var (
+	devicesMu sync.Mutex
+	devices   = make(map[deviceKey]Device)
+	typeNames = make(map[int8]string)
+)
+
+// NewDevice returns a Device of a string like "cpu", "cuda", or "cuda:1".  It
+// panics if the string is invalid.
 func NewDevice(deviceType string) Device {
+	d, e := ParseDevice(deviceType)
+	if e != nil {
+		panic(e)
+	}
+	return d
+}
+
+// ParseDevice returns the Device of a string like "cpu", "cuda", or "cuda:1",
+// which Device.String returns.
+func ParseDevice(name string) (Device, error) {
+	s := C.CString(name)
+	defer C.free(unsafe.Pointer(s))
+	var t C.Device
+	if e := CheckNil("ParseDevice", unsafe.Pointer(C.Torch_Device(s, &t))); e != nil {
+		return Device{}, e
+	}
+	defer C.Device_Close(t)
+	var typ, index C.int8_t
+	C.Device_Info(t, &typ, &index)
+	return device(int8(typ), int8(index)), nil
+}
+
+// device returns the shared Device of typ, a c10::DeviceType, and index.
+func device(typ, index int8) Device {
+	devicesMu.Lock()
+	defer devicesMu.Unlock()
+	k := deviceKey{typ, index}
+	if d, ok := devices[k]; ok {
+		return d
+	}
 	var t C.Device
-	MustNil(unsafe.Pointer(C.Torch_Device(C.CString(deviceType), &t)))
-	return Device{t}
+	MustNil(unsafe.Pointer(C.Torch_DeviceOf(C.int8_t(typ), C.int8_t(index), &t)))
+	devices[k] = Device{t}
+	return devices[k]
+}
+
+// Devices returns the CPU and the CUDA devices
+func Devices() []Device {
+	r := []Device{device(0, -1)}
+	for i := 0; i < int(C.CUDA_DeviceCount()); i++ {
+		r = append(r, device(1, int8(i)))
+	}
+	return r
+}
+
+func (d Device) info() (typ, index int8) {
+	var t, i C.int8_t
+	C.Device_Info(d.T, &t, &i)
+	return int8(t), int8(i)
+}
+
+// Type returns the device type, e.g., "cpu" or "cuda"
+func (d Device) Type() string {
+	t, _ := d.info()
+	return deviceTypeName(t)
+}
+
+// Index returns the index of the device, or -1 if not specified, which
+// means the current device of the type
+func (d Device) Index() int {
+	_, i := d.info()
+	return int(i)
+}
+
+// String returns the string that NewDevice accepts, e.g., "cpu" or "cuda:0"
+func (d Device) String() string {
+	if d.T == nil {
+		return "invalid"
+	}
+	t, i := d.info()
+	return deviceName(int(t), int(i))
+}
+
+// Equal returns if d and other have the same type and index
+func (d Device) Equal(other Device) bool {
+	if d.T == nil || other.T == nil {
+		return d.T == other.T
+	}
+	t1, i1 := d.info()
+	t2, i2 := other.info()
+	return t1 == t2 && i1 == i2
+}
+
+// GobEncode encodes the string of the device
+func (d Device) GobEncode() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// GobDecode decodes the string of a device
+func (d *Device) GobDecode(buf []byte) error {
+	r, e := ParseDevice(string(buf))
+	if e != nil {
+		return e
+	}
+	*d = r
+	return nil
+}
+
+// MarshalJSON encodes the device as a JSON string like "cuda:0"
+func (d Device) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a JSON string like "cuda:0"
+func (d *Device) UnmarshalJSON(buf []byte) error {
+	var s string
+	if e := json.Unmarshal(buf, &s); e != nil {
+		return e
+	}
+	return d.GobDecode([]byte(s))
+}
+
+// Device returns the device where a is
+func (a Tensor) Device() Device {
+	var typ, index C.int8_t
+	C.Tensor_Device(C.Tensor(*a.T), &typ, &index)
+	return device(int8(typ), int8(index))
+}
+
+// deviceTypeName returns the lower case name of a c10::DeviceType.
+func deviceTypeName(typ int8) string {
+	devicesMu.Lock()
+	defer devicesMu.Unlock()
+	if n, ok := typeNames[typ]; ok {
+		return n
+	}
+	s := C.Device_TypeName(C.int8_t(typ))
+	defer C.FreeString(s)
+	typeNames[typ] = C.GoString(s)
+	return typeNames[typ]
+}
+
+// deviceName returns the name of a c10::DeviceType and an index, e.g.,
+// "cuda:0".
+func deviceName(typ, index int) string {
+	name := deviceTypeName(int8(typ))
+	if index >= 0 {
+		name = fmt.Sprintf("%s:%d", name, index)
+	}
+	return name
 }
 
 // IsCUDAAvailable returns true if CUDA is available
@@ -1,6 +1,10 @@
 package gotorch_test
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,3 +40,53 @@ func TestDeviceIsCUDNNAvailable(t *testing.T) {
 		t.Log("No CUDNN found")
 	}
 }
+
+func TestDeviceIntrospection(t *testing.T) {
+	a := assert.New(t)
+	cpu := torch.NewDevice("cpu")
+	a.Equal("cpu", cpu.Type())
+	a.Equal(-1, cpu.Index())
+	a.Equal("cpu", cpu.String())
+
+	x := torch.NewTensor([]float32{1, 2})
+	a.True(x.Device().Equal(cpu))
+	a.True(x.Device() == cpu)
+
+	cuda1 := torch.NewDevice("cuda:1")
+	a.Equal("cuda", cuda1.Type())
+	a.Equal(1, cuda1.Index())
+	a.Equal("cuda:1", cuda1.String())
+	a.False(cuda1.Equal(cpu))
+	a.False(cuda1.Equal(torch.NewDevice("cuda")))
+
+	_, e := torch.ParseDevice("tpu:0")
+	a.Error(e)
+
+	devices := torch.Devices()
+	a.Equal(cpu, devices[0])
+	for i, d := range devices[1:] {
+		a.Equal(fmt.Sprintf("cuda:%d", i), d.String())
+	}
+}
+
+func TestDeviceEncoding(t *testing.T) {
+	a := assert.New(t)
+	type checkpoint struct {
+		Device torch.Device
+	}
+	c := checkpoint{torch.NewDevice("cuda:0")}
+
+	var buf bytes.Buffer
+	a.NoError(gob.NewEncoder(&buf).Encode(c))
+	var g checkpoint
+	a.NoError(gob.NewDecoder(&buf).Decode(&g))
+	a.True(c.Device.Equal(g.Device))
+
+	j, e := json.Marshal(c)
+	a.NoError(e)
+	a.Equal(`{"Device":"cuda:0"}`, string(j))
+	var d checkpoint
+	a.NoError(json.Unmarshal(j, &d))
+	a.True(c.Device.Equal(d.Device))
+	a.Error(json.Unmarshal([]byte(`{"Device":"unknown"}`), &d))
+}
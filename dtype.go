@@ -1,16 +1,28 @@
 package gotorch
 
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
 import (
+	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"unsafe"
 
 	"github.com/wangkuiyi/gotorch/variadic"
 )
 
+// Dtype is the data type of tensor elements, torch.dtype.  Its values are
+// those of c10::ScalarType.
+type Dtype int8
+
 const (
 	// Byte Dtype 0
-	Byte int8 = iota
+	Byte Dtype = iota
 	// Char Dtype 1
 	Char
 	// Short Dtype 2
@@ -42,28 +54,129 @@ const (
 	// BFloat16 Dtype 15
 	BFloat16
 	// Invalid Dtype
-	Invalid = -1
+	Invalid Dtype = -1
 )
 
+var dtypeInfo = []struct {
+	name     string
+	itemSize int
+}{
+	Byte:          {"uint8", 1},
+	Char:          {"int8", 1},
+	Short:         {"int16", 2},
+	Int:           {"int32", 4},
+	Long:          {"int64", 8},
+	Half:          {"float16", 2},
+	Float:         {"float32", 4},
+	Double:        {"float64", 8},
+	ComplexHalf:   {"complex32", 4},
+	ComplexFloat:  {"complex64", 8},
+	ComplexDouble: {"complex128", 16},
+	Bool:          {"bool", 1},
+	QInt8:         {"qint8", 1},
+	QUInt8:        {"quint8", 1},
+	QInt32:        {"qint32", 4},
+	BFloat16:      {"bfloat16", 2},
+}
+
+func (d Dtype) valid() bool {
+	return d >= 0 && int(d) < len(dtypeInfo)
+}
+
+// String returns the name of d as in PyTorch without the prefix "torch.",
+// e.g., "float32".
+func (d Dtype) String() string {
+	if d.valid() {
+		return dtypeInfo[d].name
+	}
+	if d == Invalid {
+		return "invalid"
+	}
+	return fmt.Sprintf("Dtype(%d)", int8(d))
+}
+
+// ItemSize returns the size of an element in bytes, or 0 for Invalid.
+func (d Dtype) ItemSize() int {
+	if d.valid() {
+		return dtypeInfo[d].itemSize
+	}
+	return 0
+}
+
+// IsFloatingPoint returns true for Half, Float, Double and BFloat16.
+func (d Dtype) IsFloatingPoint() bool {
+	return d == Half || d == Float || d == Double || d == BFloat16
+}
+
+// IsComplex returns true for ComplexHalf, ComplexFloat and ComplexDouble.
+func (d Dtype) IsComplex() bool {
+	return d == ComplexHalf || d == ComplexFloat || d == ComplexDouble
+}
+
+// dtypeAliases maps the names and the aliases of dtypes in PyTorch.
+var dtypeAliases = map[string]Dtype{
+	"byte":    Byte,
+	"char":    Char,
+	"short":   Short,
+	"int":     Int,
+	"long":    Long,
+	"half":    Half,
+	"float":   Float,
+	"double":  Double,
+	"cfloat":  ComplexFloat,
+	"cdouble": ComplexDouble,
+}
+
+// ParseDtype returns the Dtype of a name like "float32", "float", or
+// "torch.float32".
+func ParseDtype(name string) (Dtype, error) {
+	name = strings.TrimPrefix(name, "torch.")
+	for i, info := range dtypeInfo {
+		if info.name == name {
+			return Dtype(i), nil
+		}
+	}
+	if d, ok := dtypeAliases[name]; ok {
+		return d, nil
+	}
+	return Invalid, fmt.Errorf("ParseDtype: unknown dtype %q", name)
+}
+
+// PromoteTypes returns the dtype of the result of an arithmetic operation on
+// operands of dtypes a and b, torch.promote_types.
+func PromoteTypes(a, b Dtype) Dtype {
+	var r C.int8_t
+	MustNil(unsafe.Pointer(C.PromoteTypes(C.int8_t(a), C.int8_t(b), &r)))
+	return Dtype(r)
+}
+
 // NewTensor creates a tensor from a Go slice.  We use variadic parameters of
-// type map[string]interface{} to mimic named variadic parameters.
+// type map[string]interface{} to mimic named variadic parameters.  The option
+// "dtype", a Dtype or its name, overrides the dtype derived from the Go
+// element type, and must have the same element size; for example, it makes
+// a []uint16 or a []float16.Float16 a BFloat16 tensor rather than Half.  The
+// option "requires_grad" makes the returned tensor require gradients.
 func NewTensor(data interface{}, options ...map[string]interface{}) Tensor {
 	t := reflect.TypeOf(data)
 	if t.Kind() != reflect.Slice {
 		log.Panicf("NewTensor requires a slice; got a %v", t.Kind())
 	}
 
-	// TODO(wangkuiyi): Add Tensor.{SetRequiresGrad,RequiresGrad}.
-	// requireGrad := variadic.Get(options, "requires_grad").(bool)
-
 	shape, kind := sliceShapeAndElemKind(data)
 	dtype := tensorElemDType(options, kind)
 	if dtype == Invalid {
 		log.Panicf("Unrecognized element kind %v", kind)
 	}
+	if goDtype, ok := goTypeToTorch[kind]; ok && goDtype.ItemSize() != dtype.ItemSize() {
+		log.Panicf("NewTensor: dtype %v mismatches Go element kind %v", dtype, kind)
+	}
 	f := flattenSlice(data, kind)
 	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&f))
-	return FromBlob(unsafe.Pointer(hdr.Data), dtype, shape)
+	r := FromBlob(unsafe.Pointer(hdr.Data), dtype, shape)
+	if rg, ok := variadic.Lookup(options, "requires_grad"); ok && rg.(bool) {
+		r.SetRequiresGrad(true)
+	}
+	return r
 }
 
 func sliceShapeAndElemKind(data interface{}) ([]int64, reflect.Kind) {
@@ -77,17 +190,25 @@ func sliceShapeAndElemKind(data interface{}) ([]int64, reflect.Kind) {
 		r = append(r, int64(v.Len()))
 		v = v.Index(0)
 	}
-	return nil, reflect.Invalid
 }
 
-func tensorElemDType(opts []map[string]interface{}, k reflect.Kind) int8 {
+func tensorElemDType(opts []map[string]interface{}, k reflect.Kind) Dtype {
 	// The user specified DType, if there is any, overrides the one derived
 	// from Go reflection.
-	//
-	// TODO(wangkuiyi): Check the size of the specified Dtype matches that
-	// of the Go element type.
 	if dtype, ok := variadic.Lookup(opts, "dtype"); ok {
-		return dtype.(int8)
+		switch d := dtype.(type) {
+		case Dtype:
+			return d
+		case int8:
+			return Dtype(d)
+		case string:
+			r, e := ParseDtype(d)
+			if e != nil {
+				log.Panic(e)
+			}
+			return r
+		}
+		log.Panicf("NewTensor: unsupported dtype option %v", dtype)
 	}
 	dtype, ok := goTypeToTorch[k]
 	if !ok {
@@ -98,16 +219,18 @@ func tensorElemDType(opts []map[string]interface{}, k reflect.Kind) int8 {
 
 var (
 	// https://pytorch.org/docs/stable/tensors.html#torch-tensor
-	goTypeToTorch = map[reflect.Kind]int8{
-		reflect.Bool:    Bool,
-		reflect.Uint8:   Byte, // There is no reflect.Byte
-		reflect.Int8:    Char,
-		reflect.Int16:   Short,
-		reflect.Int32:   Int,
-		reflect.Int64:   Long,
-		reflect.Uint16:  Half, // TODO: add Bfloat16.
-		reflect.Float32: Float,
-		reflect.Float64: Double,
+	goTypeToTorch = map[reflect.Kind]Dtype{
+		reflect.Bool:       Bool,
+		reflect.Uint8:      Byte, // There is no reflect.Byte
+		reflect.Int8:       Char,
+		reflect.Int16:      Short,
+		reflect.Int32:      Int,
+		reflect.Int64:      Long,
+		reflect.Uint16:     Half, // BFloat16 requires the option "dtype".
+		reflect.Float32:    Float,
+		reflect.Float64:    Double,
+		reflect.Complex64:  ComplexFloat,
+		reflect.Complex128: ComplexDouble,
 	}
 )
 
@@ -145,6 +268,12 @@ func flattenSlice(slc interface{}, kind reflect.Kind) unsafe.Pointer {
 	case reflect.Float64:
 		f := flattenSliceFloat64(nil, reflect.ValueOf(slc))
 		return unsafe.Pointer((*reflect.SliceHeader)(unsafe.Pointer(&f)).Data)
+	case reflect.Complex64:
+		f := flattenSliceComplex64(nil, reflect.ValueOf(slc))
+		return unsafe.Pointer((*reflect.SliceHeader)(unsafe.Pointer(&f)).Data)
+	case reflect.Complex128:
+		f := flattenSliceComplex128(nil, reflect.ValueOf(slc))
+		return unsafe.Pointer((*reflect.SliceHeader)(unsafe.Pointer(&f)).Data)
 	}
 	return nil
 }
@@ -247,3 +376,25 @@ func flattenSliceFloat64(args []float64, v reflect.Value) []float64 {
 	}
 	return args
 }
+
+func flattenSliceComplex64(args []complex64, v reflect.Value) []complex64 {
+	if v.Kind() == reflect.Array || v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			args = flattenSliceComplex64(args, v.Index(i))
+		}
+	} else {
+		args = append(args, complex64(v.Complex()))
+	}
+	return args
+}
+
+func flattenSliceComplex128(args []complex128, v reflect.Value) []complex128 {
+	if v.Kind() == reflect.Array || v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			args = flattenSliceComplex128(args, v.Index(i))
+		}
+	} else {
+		args = append(args, v.Complex())
+	}
+	return args
+}
@@ -93,8 +93,60 @@ func TestNewTensorUnsupportGoTypes(t *testing.T) {
 	assert.Panics(t, func() { NewTensor([]uint64{1, 0}) })
 	assert.Panics(t, func() { NewTensor([]uintptr{1, 0}) })
 	assert.Panics(t, func() { NewTensor([]int{1, 0}) })
+}
+
+func TestDtypeNamesAndSizes(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("float32", Float.String())
+	a.Equal("bfloat16", BFloat16.String())
+	a.Equal("invalid", Invalid.String())
+	a.Equal(4, Float.ItemSize())
+	a.Equal(8, Long.ItemSize())
+	a.Equal(1, Bool.ItemSize())
+	a.True(Half.IsFloatingPoint())
+	a.False(Int.IsFloatingPoint())
+	a.True(ComplexFloat.IsComplex())
+	a.False(Double.IsComplex())
+}
+
+func TestParseDtype(t *testing.T) {
+	a := assert.New(t)
+	for name, expected := range map[string]Dtype{
+		"float32":      Float,
+		"float":        Float,
+		"torch.double": Double,
+		"bfloat16":     BFloat16,
+		"long":         Long,
+	} {
+		d, e := ParseDtype(name)
+		a.NoError(e)
+		a.Equal(expected, d, name)
+	}
+	_, e := ParseDtype("float128")
+	a.Error(e)
+}
+
+func TestPromoteTypes(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(Float, PromoteTypes(Int, Float))
+	a.Equal(Double, PromoteTypes(Long, Double))
+	a.Equal(Byte, PromoteTypes(Bool, Byte))
+}
 
-	// TODO(wangkuiyi): Need to support complex64
-	assert.Panics(t, func() { NewTensor([]complex64{1 + 1i, -1 - 1i}) })
-	assert.Panics(t, func() { NewTensor([]complex128{1 + 1i, -1 - 1i}) })
+func TestNewTensorWithDtypeOption(t *testing.T) {
+	a := assert.New(t)
+	x := NewTensor([]float64{1, 2}, map[string]interface{}{"dtype": "float64"})
+	a.Equal(Double, x.Dtype())
+
+	b := NewTensor([]uint16{0x3f80, 0x4000},
+		map[string]interface{}{"dtype": BFloat16})
+	a.Equal(BFloat16, b.Dtype())
+	a.Equal([]uint16{0x3f80, 0x4000}, b.BFloat16s())
+	a.Equal([]float32{1, 2}, b.Float32s())
+	a.Equal([]float16.Float16{float16.Fromfloat32(1), float16.Fromfloat32(2)},
+		b.Float16s())
+
+	a.Panics(func() {
+		NewTensor([]float32{1, 2}, map[string]interface{}{"dtype": Double})
+	})
 }
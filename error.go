@@ -0,0 +1,120 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// TorchError is the error reported by a libtorch operation.  It carries the
+// name of the operation, the shapes and dtypes of the input tensors, and the
+// message of the C++ exception.
+type TorchError struct {
+	// Op is the name of the operation, e.g., "Add" or "MM".  It is empty
+	// if the error comes from MustNil, which doesn't know the operation.
+	Op string
+	// Shapes and Dtypes describe the input tensors of the operation.
+	// Undefined tensors have nil shapes and Dtype Invalid.
+	Shapes [][]int64
+	Dtypes []Dtype
+	// Message is what the C++ exception std::exception::what() returns.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *TorchError) Error() string {
+	if e.Op == "" {
+		return e.Message
+	}
+	in := make([]string, len(e.Shapes))
+	for i := range e.Shapes {
+		in[i] = fmt.Sprintf("%v:%v", e.Shapes[i], e.Dtypes[i])
+	}
+	return fmt.Sprintf("%s(%s): %s", e.Op, strings.Join(in, ", "), e.Message)
+}
+
+// CheckNil returns nil if err is nil; otherwise, it frees the C string err and
+// returns a *TorchError describing the failed operation op on inputs.  It is
+// the error-returning counterpart of MustNil.
+func CheckNil(op string, err unsafe.Pointer, inputs ...Tensor) error {
+	if err == nil {
+		return nil
+	}
+	msg := C.GoString((*C.char)(err))
+	C.FreeString((*C.char)(err))
+	return newTorchError(op, msg, inputs...)
+}
+
+// newTorchError returns the *TorchError describing the failed operation op on
+// inputs, e.g., for invalid options detected before calling libtorch.
+func newTorchError(op, msg string, inputs ...Tensor) *TorchError {
+	e := &TorchError{
+		Op:      op,
+		Shapes:  make([][]int64, len(inputs)),
+		Dtypes:  make([]Dtype, len(inputs)),
+		Message: msg,
+	}
+	for i, t := range inputs {
+		e.Dtypes[i] = Invalid
+		if t.T != nil && *t.T != nil {
+			e.Shapes[i] = t.Shape()
+			e.Dtypes[i] = t.Dtype()
+		}
+	}
+	return e
+}
+
+// Must panics if err is not nil, otherwise it returns t.  It converts the
+// result of a TryXxx function into that of the corresponding Xxx function.
+func Must(t Tensor, err error) Tensor {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Try calls f and returns the *TorchError if f panics because of a failed
+// libtorch operation.  Other panics pass through.  Try makes it possible to
+// reject a bad request in a long-running service without bringing down the
+// process:
+//
+//	err := torch.Try(func() { y = model.Forward(x) })
+//	if err != nil {
+//		return err
+//	}
+func Try(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*TorchError)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	f()
+	return nil
+}
+
+// NewTensorOrError wraps the C tensor at t if err, the error message returned
+// by a cgotorch function, is nil, or returns the *TorchError describing the
+// failed operation op on inputs.  Packages like linalg and nn/functional call
+// it to implement their Try functions.
+func NewTensorOrError(op string, err unsafe.Pointer, t *unsafe.Pointer,
+	inputs ...Tensor) (Tensor, error) {
+	if e := CheckNil(op, err, inputs...); e != nil {
+		return Tensor{}, e
+	}
+	SetTensorFinalizer(t)
+	return Tensor{t}, nil
+}
+
+func newTensorOrError(op string, err *C.char, t *C.Tensor, inputs ...Tensor) (Tensor, error) {
+	return NewTensorOrError(op, unsafe.Pointer(err), (*unsafe.Pointer)(t), inputs...)
+}
@@ -0,0 +1,85 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestTryAddShapeMismatch(t *testing.T) {
+	a := assert.New(t)
+	x := torch.RandN([]int64{2, 3}, false)
+	y := torch.RandN([]int64{4, 5}, false)
+
+	z, err := torch.TryAdd(x, y, 1)
+	a.Error(err)
+	a.Nil(z.T)
+
+	e, ok := err.(*torch.TorchError)
+	a.True(ok)
+	a.Equal("Add", e.Op)
+	a.Equal([][]int64{{2, 3}, {4, 5}}, e.Shapes)
+	a.Equal([]torch.Dtype{torch.Float, torch.Float}, e.Dtypes)
+	a.NotEmpty(e.Message)
+	a.Contains(err.Error(), "Add([2 3]:float32, [4 5]:float32)")
+
+	z, err = torch.TryAdd(x, x, 1)
+	a.NoError(err)
+	a.Equal([]int64{2, 3}, z.Shape())
+}
+
+func TestTryMM(t *testing.T) {
+	a := assert.New(t)
+	_, err := torch.TryMM(torch.RandN([]int64{2, 3}, false),
+		torch.RandN([]int64{2, 3}, false))
+	a.Error(err)
+	a.Equal("MM", err.(*torch.TorchError).Op)
+	a.Panics(func() {
+		torch.MM(torch.RandN([]int64{2, 3}, false), torch.RandN([]int64{2, 3}, false))
+	})
+}
+
+func TestTryIndex(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+	_, err := x.TryIndex(0)
+	a.Error(err)
+	_, err = x.TryIndex(2, 0)
+	a.Error(err)
+	y, err := x.TryIndex(1, 0)
+	a.NoError(err)
+	a.Equal(float32(3), y.Item())
+}
+
+func TestTryEmptySlices(t *testing.T) {
+	a := assert.New(t)
+	_, err := torch.TryStack(nil, 0)
+	a.Error(err)
+
+	x := torch.NewTensor([]float32{7})
+	y, err := torch.TryView(x)
+	a.NoError(err)
+	a.Equal(0, len(y.Shape()))
+	a.Equal(float32(7), y.Item())
+
+	_, err = torch.TrySqueeze(x, 0, 1)
+	a.Error(err)
+	_, err = torch.TryEmpty([]int64{-1}, false)
+	a.Error(err)
+}
+
+func TestTry(t *testing.T) {
+	a := assert.New(t)
+	x := torch.RandN([]int64{2, 3}, false)
+
+	err := torch.Try(func() { x.View(7) })
+	a.Error(err)
+	_, ok := err.(*torch.TorchError)
+	a.True(ok)
+
+	a.NoError(torch.Try(func() { x.View(6) }))
+
+	// Panics other than failed libtorch operations pass through.
+	a.Panics(func() { torch.Try(func() { panic("not a torch error") }) })
+}
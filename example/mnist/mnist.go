@@ -104,6 +104,8 @@ func test(model *models.MLPModule, loader *imageloader.ImageLoader) {
 	testLoss := float32(0)
 	correct := int64(0)
 	samples := 0
+	g := torch.NewNoGradGuard()
+	defer g.Close()
 	for loader.Scan() {
 		data, label := loader.Minibatch()
 		data = data.To(device, data.Dtype())
@@ -133,6 +133,8 @@ func validate(model *models.ResnetModule, loader *imageloader.ImageLoader, epoch
 	avgAcc1 := newAverageMeter("acc1")
 	avgAcc5 := newAverageMeter("acc5")
 	iters := 0
+	g := torch.NewNoGradGuard()
+	defer g.Close()
 	for loader.Scan() {
 		data, label := loader.Minibatch()
 		data = data.To(device, data.Dtype())
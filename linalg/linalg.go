@@ -0,0 +1,215 @@
+// Package linalg wraps linear algebra functions of libtorch.  Like other
+// GoTorch functions, each function Xxx panics if libtorch reports an error,
+// and its counterpart TryXxx returns the error as a *torch.TorchError.
+package linalg
+
+// #cgo CFLAGS: -I ${SRCDIR}/..
+// #cgo LDFLAGS: -L ${SRCDIR}/../cgotorch -Wl,-rpath ${SRCDIR}/../cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/../cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/../cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include <stdlib.h>
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"unsafe"
+
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func tensorOrError(op string, err *C.char, t *C.Tensor, inputs ...torch.Tensor) (torch.Tensor, error) {
+	return torch.NewTensorOrError(op, unsafe.Pointer(err), (*unsafe.Pointer)(t), inputs...)
+}
+
+func wrap(t *C.Tensor) torch.Tensor {
+	torch.SetTensorFinalizer((*unsafe.Pointer)(t))
+	return torch.Tensor{T: (*unsafe.Pointer)(t)}
+}
+
+func boolToInt8(b bool) C.int8_t {
+	return C.int8_t(torch.BoolToInt8(b))
+}
+
+// Matmul torch.matmul, which broadcasts batch dimensions
+func Matmul(a, b torch.Tensor) torch.Tensor {
+	return torch.Must(TryMatmul(a, b))
+}
+
+// TryMatmul is Matmul that returns an error rather than panics
+func TryMatmul(a, b torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Matmul",
+		C.Matmul(C.Tensor(*a.T), C.Tensor(*b.T), &t), &t, a, b)
+}
+
+// BMM torch.bmm, the batched matrix multiplication without broadcasting
+func BMM(a, b torch.Tensor) torch.Tensor {
+	return torch.Must(TryBMM(a, b))
+}
+
+// TryBMM is BMM that returns an error rather than panics
+func TryBMM(a, b torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("BMM",
+		C.BMM(C.Tensor(*a.T), C.Tensor(*b.T), &t), &t, a, b)
+}
+
+// Einsum torch.einsum
+func Einsum(equation string, tensors ...torch.Tensor) torch.Tensor {
+	return torch.Must(TryEinsum(equation, tensors...))
+}
+
+// TryEinsum is Einsum that returns an error rather than panics
+func TryEinsum(equation string, tensors ...torch.Tensor) (torch.Tensor, error) {
+	CT := torch.CTensors(tensors)
+	eq := C.CString(equation)
+	defer C.free(unsafe.Pointer(eq))
+	var t C.Tensor
+	return tensorOrError("Einsum",
+		C.Einsum(eq, (*C.Tensor)(unsafe.Pointer(&CT[0])), C.int64_t(len(tensors)), &t), &t, tensors...)
+}
+
+// Outer torch.outer, the outer product of two vectors
+func Outer(a, b torch.Tensor) torch.Tensor {
+	return torch.Must(TryOuter(a, b))
+}
+
+// TryOuter is Outer that returns an error rather than panics
+func TryOuter(a, b torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Outer",
+		C.Outer(C.Tensor(*a.T), C.Tensor(*b.T), &t), &t, a, b)
+}
+
+// Dot torch.dot, the inner product of two vectors
+func Dot(a, b torch.Tensor) torch.Tensor {
+	return torch.Must(TryDot(a, b))
+}
+
+// TryDot is Dot that returns an error rather than panics
+func TryDot(a, b torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Dot",
+		C.Dot(C.Tensor(*a.T), C.Tensor(*b.T), &t), &t, a, b)
+}
+
+// Inverse torch.inverse
+func Inverse(a torch.Tensor) torch.Tensor {
+	return torch.Must(TryInverse(a))
+}
+
+// TryInverse is Inverse that returns an error rather than panics
+func TryInverse(a torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Inverse", C.Inverse(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Det torch.det
+func Det(a torch.Tensor) torch.Tensor {
+	return torch.Must(TryDet(a))
+}
+
+// TryDet is Det that returns an error rather than panics
+func TryDet(a torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Det", C.Det(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Solve returns X that satisfies AX = B, torch.linalg.solve
+func Solve(A, B torch.Tensor) torch.Tensor {
+	return torch.Must(TrySolve(A, B))
+}
+
+// TrySolve is Solve that returns an error rather than panics
+func TrySolve(A, B torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Solve",
+		C.Solve(C.Tensor(*A.T), C.Tensor(*B.T), &t), &t, A, B)
+}
+
+// Cholesky torch.cholesky
+func Cholesky(a torch.Tensor, upper bool) torch.Tensor {
+	return torch.Must(TryCholesky(a, upper))
+}
+
+// TryCholesky is Cholesky that returns an error rather than panics
+func TryCholesky(a torch.Tensor, upper bool) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Cholesky",
+		C.Cholesky(C.Tensor(*a.T), boolToInt8(upper), &t), &t, a)
+}
+
+// QR torch.qr.  It returns the reduced decomposition if some is true.
+func QR(a torch.Tensor, some bool) (q, r torch.Tensor) {
+	q, r, e := TryQR(a, some)
+	if e != nil {
+		panic(e)
+	}
+	return q, r
+}
+
+// TryQR is QR that returns an error rather than panics
+func TryQR(a torch.Tensor, some bool) (q, r torch.Tensor, err error) {
+	var cq, cr C.Tensor
+	err = torch.CheckNil("QR", unsafe.Pointer(
+		C.QR(C.Tensor(*a.T), boolToInt8(some), &cq, &cr)), a)
+	if err != nil {
+		return q, r, err
+	}
+	return wrap(&cq), wrap(&cr), nil
+}
+
+// SVD torch.svd.  It returns U, S, and V such that a = U diag(S) V^T.
+func SVD(a torch.Tensor, some bool) (u, s, v torch.Tensor) {
+	u, s, v, e := TrySVD(a, some)
+	if e != nil {
+		panic(e)
+	}
+	return u, s, v
+}
+
+// TrySVD is SVD that returns an error rather than panics
+func TrySVD(a torch.Tensor, some bool) (u, s, v torch.Tensor, err error) {
+	var cu, cs, cv C.Tensor
+	err = torch.CheckNil("SVD", unsafe.Pointer(
+		C.SVD(C.Tensor(*a.T), boolToInt8(some), &cu, &cs, &cv)), a)
+	if err != nil {
+		return u, s, v, err
+	}
+	return wrap(&cu), wrap(&cs), wrap(&cv), nil
+}
+
+// Eigh returns the eigenvalues in ascending order and the eigenvectors of a
+// symmetric matrix, torch.linalg.eigh.  It uses the upper triangular part of
+// a if upper is true, or the lower one otherwise.
+func Eigh(a torch.Tensor, upper bool) (eigenvalues, eigenvectors torch.Tensor) {
+	eigenvalues, eigenvectors, e := TryEigh(a, upper)
+	if e != nil {
+		panic(e)
+	}
+	return eigenvalues, eigenvectors
+}
+
+// TryEigh is Eigh that returns an error rather than panics
+func TryEigh(a torch.Tensor, upper bool) (eigenvalues, eigenvectors torch.Tensor, err error) {
+	var cvalues, cvectors C.Tensor
+	err = torch.CheckNil("Eigh", unsafe.Pointer(
+		C.Eigh(C.Tensor(*a.T), boolToInt8(upper), &cvalues, &cvectors)), a)
+	if err != nil {
+		return eigenvalues, eigenvectors, err
+	}
+	return wrap(&cvalues), wrap(&cvectors), nil
+}
+
+// Pinv torch.pinverse, the Moore-Penrose pseudo-inverse.  Singular values
+// smaller than rcond times the largest singular value are treated as 0.
+// PyTorch uses the default rcond 1e-15.
+func Pinv(a torch.Tensor, rcond float64) torch.Tensor {
+	return torch.Must(TryPinv(a, rcond))
+}
+
+// TryPinv is Pinv that returns an error rather than panics
+func TryPinv(a torch.Tensor, rcond float64) (torch.Tensor, error) {
+	var t C.Tensor
+	return tensorOrError("Pinv",
+		C.Pinv(C.Tensor(*a.T), C.double(rcond), &t), &t, a)
+}
@@ -0,0 +1,87 @@
+package linalg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestMatmul(t *testing.T) {
+	a := assert.New(t)
+	x := torch.RandN([]int64{4, 2, 3}, false)
+	y := torch.RandN([]int64{3, 5}, false)
+	a.Equal([]int64{4, 2, 5}, Matmul(x, y).Shape())
+
+	_, err := TryMatmul(x, x)
+	a.Error(err)
+	a.Equal("Matmul", err.(*torch.TorchError).Op)
+
+	z := torch.RandN([]int64{4, 3, 5}, false)
+	a.Equal([]int64{4, 2, 5}, BMM(x, z).Shape())
+	a.True(torch.AllClose(Matmul(x, z), BMM(x, z)))
+	a.Panics(func() { BMM(x, y) })
+}
+
+func TestEinsum(t *testing.T) {
+	a := assert.New(t)
+	x := torch.RandN([]int64{2, 3}, false)
+	y := torch.RandN([]int64{3, 4}, false)
+	a.True(torch.AllClose(torch.MM(x, y), Einsum("ij,jk->ik", x, y)))
+	a.Equal([]int64{3, 2}, Einsum("ij->ji", x).Shape())
+	_, err := TryEinsum("ij,jk->ik", x, x)
+	a.Error(err)
+}
+
+func TestOuterDot(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 2})
+	y := torch.NewTensor([]float32{3, 4, 5})
+	a.Equal([][]float32{{3, 4, 5}, {6, 8, 10}}, Outer(x, y).ToSlice())
+	a.Equal(float32(5), Dot(x, x).Item())
+	a.Panics(func() { Dot(x, y) })
+}
+
+func TestInverseDetSolve(t *testing.T) {
+	a := assert.New(t)
+	m := torch.NewTensor([][]float64{{4, 7}, {2, 6}})
+	a.InDelta(10.0, Det(m).Item(), 1e-9)
+	a.True(torch.AllClose(torch.Eye(2, 2, false).CastTo(torch.Double),
+		torch.MM(m, Inverse(m))))
+
+	b := torch.NewTensor([][]float64{{1}, {2}})
+	x := Solve(m, b)
+	a.True(torch.AllClose(b, torch.MM(m, x)))
+
+	singular := torch.NewTensor([][]float64{{1, 2}, {2, 4}})
+	_, err := TryInverse(singular)
+	a.Error(err)
+}
+
+func TestDecompositions(t *testing.T) {
+	a := assert.New(t)
+	m := torch.NewTensor([][]float64{{4, 2}, {2, 3}})
+
+	l := Cholesky(m, false)
+	a.True(torch.AllClose(m, torch.MM(l, l.Transpose(0, 1))))
+	_, err := TryCholesky(torch.NewTensor([][]float64{{-1, 0}, {0, -1}}), false)
+	a.Error(err)
+
+	q, r := QR(m, true)
+	a.True(torch.AllClose(m, torch.MM(q, r)))
+
+	u, s, v := SVD(m, true)
+	a.True(torch.AllClose(m, torch.MM(torch.MM(u, diag(s)), v.Transpose(0, 1))))
+
+	values, vectors := Eigh(m, false)
+	a.Equal([]int64{2}, values.Shape())
+	a.True(torch.AllClose(torch.MM(m, vectors),
+		torch.MM(vectors, diag(values))))
+
+	a.True(torch.AllClose(Inverse(m), Pinv(m, 1e-15)))
+}
+
+// diag returns the diagonal matrix of the vector v.
+func diag(v torch.Tensor) torch.Tensor {
+	return Einsum("i,ij->ij", v, torch.Eye(v.Shape()[0], v.Shape()[0], false).CastTo(v.Dtype()))
+}
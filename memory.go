@@ -0,0 +1,226 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// MemoryUsage is a snapshot of the live tensors, those created but neither
+// closed nor finalized yet.  Bytes count the elements of each tensor, i.e.,
+// the number of elements times the element size, so views sharing the
+// storage with other tensors, e.g., the results of Index, Reshape, and
+// Detach, count separately, and the sums could exceed the memory allocated
+// by libtorch.
+type MemoryUsage struct {
+	LiveTensors int64
+	LiveBytes   int64
+	// PeakBytes is the maximum of LiveBytes since the program started.
+	PeakBytes     int64
+	BytesByDevice map[string]int64
+	BytesByDtype  map[Dtype]int64
+}
+
+type tensorRecord struct {
+	device string
+	dtype  Dtype
+	bytes  int64
+	// stack is the Go call stack creating the tensor in the debug mode.
+	stack []uintptr
+}
+
+// memoryShard keeps the records of a share of the live tensors, so creating
+// tensors in many goroutines doesn't contend for a single lock.
+type memoryShard struct {
+	mu sync.Mutex
+	// records are keyed by the C handles rather than the Go pointers, which
+	// must stay unreachable for the finalizers to run.
+	records       map[uintptr]tensorRecord
+	bytesByDevice map[string]int64
+	bytesByDtype  map[Dtype]int64
+}
+
+const memoryShards = 64
+
+var (
+	liveTensors int64
+	liveBytes   int64
+	peakBytes   int64
+	memoryDebug int32
+	shards      [memoryShards]memoryShard
+)
+
+func init() {
+	for i := range shards {
+		shards[i].records = make(map[uintptr]tensorRecord)
+		shards[i].bytesByDevice = make(map[string]int64)
+		shards[i].bytesByDtype = make(map[Dtype]int64)
+	}
+}
+
+func shardOf(p unsafe.Pointer) *memoryShard {
+	// C handles are aligned, so the low bits are always zero.
+	return &shards[(uintptr(p)>>4)%memoryShards]
+}
+
+// MemoryStats returns a snapshot of the memory used by live tensors.
+func MemoryStats() MemoryUsage {
+	r := MemoryUsage{
+		LiveTensors:   atomic.LoadInt64(&liveTensors),
+		LiveBytes:     atomic.LoadInt64(&liveBytes),
+		PeakBytes:     atomic.LoadInt64(&peakBytes),
+		BytesByDevice: make(map[string]int64),
+		BytesByDtype:  make(map[Dtype]int64)}
+	for i := range shards {
+		s := &shards[i]
+		s.mu.Lock()
+		for k, v := range s.bytesByDevice {
+			r.BytesByDevice[k] += v
+		}
+		for k, v := range s.bytesByDtype {
+			r.BytesByDtype[k] += v
+		}
+		s.mu.Unlock()
+	}
+	return r
+}
+
+// SetMemoryDebug turns on or off the debug mode, which records the Go call
+// stack creating each new tensor for DumpLiveTensors.  The debug mode slows
+// down the creation of tensors.
+func SetMemoryDebug(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&memoryDebug, v)
+}
+
+// DumpLiveTensors writes the top n call stacks that created the most bytes of
+// live tensors to w, to find the code leaking tensors.  Tensors created when
+// the debug mode is off are reported with an unknown call stack.
+func DumpLiveTensors(w io.Writer, n int) error {
+	type site struct {
+		stack          []uintptr
+		tensors, bytes int64
+	}
+	sites := make(map[string]*site)
+	for i := range shards {
+		sh := &shards[i]
+		sh.mu.Lock()
+		for _, r := range sh.records {
+			key := fmt.Sprint(r.stack)
+			s, ok := sites[key]
+			if !ok {
+				s = &site{stack: r.stack}
+				sites[key] = s
+			}
+			s.tensors++
+			s.bytes += r.bytes
+		}
+		sh.mu.Unlock()
+	}
+
+	sorted := make([]*site, 0, len(sites))
+	for _, s := range sites {
+		sorted = append(sorted, s)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].bytes != sorted[j].bytes {
+			return sorted[i].bytes > sorted[j].bytes
+		}
+		return sorted[i].tensors > sorted[j].tensors
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	for _, s := range sorted {
+		if _, e := fmt.Fprintf(w, "%d bytes in %d live tensors created at:\n",
+			s.bytes, s.tensors); e != nil {
+			return e
+		}
+		if len(s.stack) == 0 {
+			if _, e := fmt.Fprintf(w, "\tunknown\n"); e != nil {
+				return e
+			}
+			continue
+		}
+		frames := runtime.CallersFrames(s.stack)
+		for {
+			f, more := frames.Next()
+			if _, e := fmt.Fprintf(w, "\t%s\n\t\t%s:%d\n", f.Function, f.File, f.Line); e != nil {
+				return e
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// recordTensor adds the new tensor *t to the memory usage.
+func recordTensor(t *unsafe.Pointer) {
+	var deviceType, deviceIndex, dtype C.int8_t
+	var nbytes C.int64_t
+	C.Tensor_MemoryInfo(C.Tensor(*t), &deviceType, &deviceIndex, &dtype, &nbytes)
+	r := tensorRecord{
+		device: deviceName(int(deviceType), int(deviceIndex)),
+		dtype:  Dtype(dtype),
+		bytes:  int64(nbytes)}
+	if atomic.LoadInt32(&memoryDebug) != 0 {
+		// Skip runtime.Callers, recordTensor and SetTensorFinalizer.
+		pcs := make([]uintptr, 32)
+		r.stack = pcs[:runtime.Callers(3, pcs)]
+	}
+
+	s := shardOf(*t)
+	s.mu.Lock()
+	s.records[uintptr(*t)] = r
+	s.bytesByDevice[r.device] += r.bytes
+	s.bytesByDtype[r.dtype] += r.bytes
+	s.mu.Unlock()
+
+	atomic.AddInt64(&liveTensors, 1)
+	live := atomic.AddInt64(&liveBytes, r.bytes)
+	for {
+		peak := atomic.LoadInt64(&peakBytes)
+		if live <= peak || atomic.CompareAndSwapInt64(&peakBytes, peak, live) {
+			break
+		}
+	}
+}
+
+// forgetTensor removes the tensor with the C handle p from the memory usage.
+func forgetTensor(p unsafe.Pointer) {
+	s := shardOf(p)
+	s.mu.Lock()
+	r, ok := s.records[uintptr(p)]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.records, uintptr(p))
+	s.bytesByDevice[r.device] -= r.bytes
+	if s.bytesByDevice[r.device] == 0 {
+		delete(s.bytesByDevice, r.device)
+	}
+	s.bytesByDtype[r.dtype] -= r.bytes
+	if s.bytesByDtype[r.dtype] == 0 {
+		delete(s.bytesByDtype, r.dtype)
+	}
+	s.mu.Unlock()
+
+	atomic.AddInt64(&liveTensors, -1)
+	atomic.AddInt64(&liveBytes, -r.bytes)
+}
@@ -0,0 +1,67 @@
+package gotorch_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestMemoryStats(t *testing.T) {
+	a := assert.New(t)
+	// Other tests hardly leave Short tensors to the finalizers, so the bytes
+	// of Short tensors don't change during the test except for x.
+	before := torch.MemoryStats()
+	x := torch.NewTensor(make([]int16, 1000))
+	s := torch.MemoryStats()
+	a.True(s.LiveTensors > 0)
+	a.True(s.LiveBytes >= 2000)
+	a.True(s.PeakBytes >= s.LiveBytes)
+	a.True(s.BytesByDevice["cpu"] >= 2000)
+	a.Equal(before.BytesByDtype[torch.Short]+2000, s.BytesByDtype[torch.Short])
+
+	x.Close()
+	a.Equal(before.BytesByDtype[torch.Short], torch.MemoryStats().BytesByDtype[torch.Short])
+	x.Close()
+	a.Equal(before.BytesByDtype[torch.Short], torch.MemoryStats().BytesByDtype[torch.Short])
+}
+
+func TestFinalizersRunWithMemoryDebug(t *testing.T) {
+	a := assert.New(t)
+	torch.SetMemoryDebug(true)
+	defer torch.SetMemoryDebug(false)
+	for i := 0; i < 100; i++ {
+		torch.NewTensor(make([]float32, 1<<10))
+	}
+	// The records of the tensors must not keep them reachable.
+	for i := 0; i < 10 && torch.MemoryStats().BytesByDtype[torch.Float] >= 100<<12; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	a.True(torch.MemoryStats().BytesByDtype[torch.Float] < 100<<12)
+}
+
+func TestDumpLiveTensors(t *testing.T) {
+	a := assert.New(t)
+	torch.SetMemoryDebug(true)
+	defer torch.SetMemoryDebug(false)
+
+	var leaks []torch.Tensor
+	for i := 0; i < 3; i++ {
+		leaks = append(leaks, torch.NewTensor(make([]float32, 1<<20)))
+	}
+	var buf bytes.Buffer
+	a.NoError(torch.DumpLiveTensors(&buf, 1))
+	a.Contains(buf.String(), "12582912 bytes in 3 live tensors created at:")
+	a.Contains(buf.String(), "TestDumpLiveTensors")
+
+	for _, l := range leaks {
+		l.Close()
+	}
+	buf.Reset()
+	a.NoError(torch.DumpLiveTensors(&buf, 100))
+	a.NotContains(buf.String(), "TestDumpLiveTensors")
+}
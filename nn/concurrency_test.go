@@ -0,0 +1,38 @@
+package nn
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestConcurrentForwardInEvalMode(t *testing.T) {
+	a := assert.New(t)
+	m := Sequential(Linear(4, 8, true), Functional(torch.Relu),
+		BatchNorm2d(8, 1e-5, 0.1, true, true), Linear(8, 2, true))
+	m.Train(false)
+	x := torch.RandN([]int64{3, 4}, false)
+	forward := func() torch.Tensor {
+		return m.Forward(x).(torch.Tensor)
+	}
+	var expected []float32
+	torch.NoGrad(func() { expected = forward().Float32s() })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 20; k++ {
+				torch.NoGrad(func() {
+					torch.Scope(func(s *torch.TensorScope) {
+						a.Equal(expected, forward().Float32s())
+					})
+				})
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -3,6 +3,7 @@ package functional
 // #cgo CFLAGS: -I ${SRCDIR}/../..
 // #cgo LDFLAGS: -L ${SRCDIR}/../../cgotorch -Wl,-rpath ${SRCDIR}/../../cgotorch -lcgotorch
 // #cgo LDFLAGS: -L ${SRCDIR}/../../cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/../../cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include <stdlib.h>
 // #include "cgotorch/cgotorch.h"
 import "C"
 
@@ -13,61 +14,70 @@ import (
 	torch "github.com/wangkuiyi/gotorch"
 )
 
+// optional returns the C tensor of t, or nil if t is undefined.
+func optional(t torch.Tensor) C.Tensor {
+	if t.T == nil {
+		return nil
+	}
+	return C.Tensor(*t.T)
+}
+
+// dims returns the C array of the integer slice d.
+func dims(d []int64) *C.int64_t {
+	return (*C.int64_t)(torch.DimsPtr(d))
+}
+
+// tensorOrError wraps torch.NewTensorOrError for the C types of this package.
+func tensorOrError(op string, err *C.char, t *C.Tensor, inputs ...torch.Tensor) (torch.Tensor, error) {
+	return torch.NewTensorOrError(op, unsafe.Pointer(err), (*unsafe.Pointer)(t), inputs...)
+}
+
 // BatchNorm does batch nomalization for `input`
 func BatchNorm(input, runningMean, runningVar, weight, bias torch.Tensor,
 	training bool, momentum, eps float64) torch.Tensor {
-	var cTraining C.int8_t
-	if training {
-		cTraining = 1
-	}
-	var cweight, cbias, cmean, cvar, t C.Tensor
-	if runningMean.T != nil {
-		cmean = C.Tensor(*runningMean.T)
-	}
-	if runningVar.T != nil {
-		cvar = C.Tensor(*runningVar.T)
-	}
-	if weight.T != nil {
-		cweight = C.Tensor(*weight.T)
-	}
-	if bias.T != nil {
-		cbias = C.Tensor(*bias.T)
-	}
-	torch.MustNil(
-		unsafe.Pointer(C.BatchNorm(
-			C.Tensor(*input.T),
-			cweight,
-			cbias,
-			cmean,
-			cvar,
-			cTraining,
-			C.double(momentum),
-			C.double(eps),
-			&t)))
+	return torch.Must(TryBatchNorm(input, runningMean, runningVar, weight, bias,
+		training, momentum, eps))
+}
+
+// TryBatchNorm is BatchNorm that returns an error rather than panics
+func TryBatchNorm(input, runningMean, runningVar, weight, bias torch.Tensor,
+	training bool, momentum, eps float64) (torch.Tensor, error) {
+	var t C.Tensor
+	r, err := tensorOrError("BatchNorm", C.BatchNorm(
+		C.Tensor(*input.T),
+		optional(weight),
+		optional(bias),
+		optional(runningMean),
+		optional(runningVar),
+		C.int8_t(torch.BoolToInt8(training)),
+		C.double(momentum),
+		C.double(eps),
+		&t), &t, input, runningMean, runningVar, weight, bias)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
 
 // Conv2d does 2d-convolution
 func Conv2d(input, weight, bias torch.Tensor,
 	stride, padding, dilation []int64, groups int64) torch.Tensor {
-	var cbias, t C.Tensor
-	if bias.T != nil {
-		cbias = C.Tensor(*bias.T)
-	}
-	torch.MustNil(unsafe.Pointer(C.Conv2d(
+	return torch.Must(TryConv2d(input, weight, bias, stride, padding, dilation, groups))
+}
+
+// TryConv2d is Conv2d that returns an error rather than panics
+func TryConv2d(input, weight, bias torch.Tensor,
+	stride, padding, dilation []int64, groups int64) (torch.Tensor, error) {
+	var t C.Tensor
+	r, err := tensorOrError("Conv2d", C.Conv2d(
 		C.Tensor(*input.T),
 		C.Tensor(*weight.T),
-		cbias,
-		(*C.int64_t)(unsafe.Pointer(&stride[0])), C.int64_t(len(stride)),
-		(*C.int64_t)(unsafe.Pointer(&padding[0])), C.int64_t(len(padding)),
-		(*C.int64_t)(unsafe.Pointer(&dilation[0])), C.int64_t(len(dilation)),
+		optional(bias),
+		dims(stride), C.int64_t(len(stride)),
+		dims(padding), C.int64_t(len(padding)),
+		dims(dilation), C.int64_t(len(dilation)),
 		C.int64_t(groups),
-		&t)))
+		&t), &t, input, weight, bias)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
 
 // ConvTranspose2d does 2d-fractionally-strided convolution
@@ -75,29 +85,29 @@ func ConvTranspose2d(
 	input, weight, bias torch.Tensor,
 	stride, padding, outputPadding []int64,
 	groups int64, dilation []int64) torch.Tensor {
+	return torch.Must(TryConvTranspose2d(input, weight, bias,
+		stride, padding, outputPadding, groups, dilation))
+}
 
-	var cbias, t C.Tensor
-	if bias.T != nil {
-		cbias = C.Tensor(*bias.T)
-	}
-
-	torch.MustNil(unsafe.Pointer(C.ConvTranspose2d(
+// TryConvTranspose2d is ConvTranspose2d that returns an error rather than
+// panics
+func TryConvTranspose2d(
+	input, weight, bias torch.Tensor,
+	stride, padding, outputPadding []int64,
+	groups int64, dilation []int64) (torch.Tensor, error) {
+	var t C.Tensor
+	r, err := tensorOrError("ConvTranspose2d", C.ConvTranspose2d(
 		C.Tensor(*input.T),
 		C.Tensor(*weight.T),
-		cbias,
-		(*C.int64_t)(unsafe.Pointer(&stride[0])),
-		C.int64_t(len(stride)),
-		(*C.int64_t)(unsafe.Pointer(&padding[0])),
-		C.int64_t(len(padding)),
-		(*C.int64_t)(unsafe.Pointer(&outputPadding[0])),
-		C.int64_t(len(outputPadding)),
+		optional(bias),
+		dims(stride), C.int64_t(len(stride)),
+		dims(padding), C.int64_t(len(padding)),
+		dims(outputPadding), C.int64_t(len(outputPadding)),
 		C.int64_t(groups),
-		(*C.int64_t)(unsafe.Pointer(&dilation[0])),
-		C.int64_t(len(dilation)),
-		&t)))
+		dims(dilation), C.int64_t(len(dilation)),
+		&t), &t, input, weight, bias)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
 
 // LogSoftmax torch.nn.functional.log_softmax
@@ -116,131 +126,150 @@ func LogSoftmax(input torch.Tensor, dim int64) torch.Tensor {
 // NllLoss torch.nn.functional.nll_loss
 func NllLoss(input, target, weight torch.Tensor, ignoreIndex int64,
 	reduction string) torch.Tensor {
-	var cweight, t C.Tensor
-	if weight.T != nil {
-		cweight = C.Tensor(*weight.T)
-	}
-	torch.MustNil(unsafe.Pointer(C.NllLoss(
+	return torch.Must(TryNllLoss(input, target, weight, ignoreIndex, reduction))
+}
+
+// TryNllLoss is NllLoss that returns an error rather than panics
+func TryNllLoss(input, target, weight torch.Tensor, ignoreIndex int64,
+	reduction string) (torch.Tensor, error) {
+	r := C.CString(reduction)
+	defer C.free(unsafe.Pointer(r))
+	var t C.Tensor
+	result, err := tensorOrError("NllLoss", C.NllLoss(
 		C.Tensor(*input.T),
 		C.Tensor(*target.T),
-		cweight,
+		optional(weight),
 		C.int64_t(ignoreIndex),
-		C.CString(reduction),
-		&t)))
+		r,
+		&t), &t, input, target, weight)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return result, err
 }
 
 // BinaryCrossEntropy torch.nn.functional.binary_cross_entropy
 func BinaryCrossEntropy(input, target, weight torch.Tensor,
 	reduction string) torch.Tensor {
-	var cweight, t C.Tensor
-	if weight.T != nil {
-		cweight = C.Tensor(*weight.T)
-	}
-	torch.MustNil(unsafe.Pointer(C.BinaryCrossEntropy(
+	return torch.Must(TryBinaryCrossEntropy(input, target, weight, reduction))
+}
+
+// TryBinaryCrossEntropy is BinaryCrossEntropy that returns an error rather
+// than panics
+func TryBinaryCrossEntropy(input, target, weight torch.Tensor,
+	reduction string) (torch.Tensor, error) {
+	r := C.CString(reduction)
+	defer C.free(unsafe.Pointer(r))
+	var t C.Tensor
+	result, err := tensorOrError("BinaryCrossEntropy", C.BinaryCrossEntropy(
 		C.Tensor(*input.T),
 		C.Tensor(*target.T),
-		cweight,
-		C.CString(reduction),
-		&t)))
+		optional(weight),
+		r,
+		&t), &t, input, target, weight)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return result, err
 }
 
 // CrossEntropy torch.nn.functional.cross_entropy
 func CrossEntropy(input, target, weight torch.Tensor, ignoreIndex int64,
 	reduction string) torch.Tensor {
-	var cweight, t C.Tensor
-	if weight.T != nil {
-		cweight = C.Tensor(*weight.T)
-	}
-	torch.MustNil(unsafe.Pointer(C.CrossEntropy(
+	return torch.Must(TryCrossEntropy(input, target, weight, ignoreIndex, reduction))
+}
+
+// TryCrossEntropy is CrossEntropy that returns an error rather than panics
+func TryCrossEntropy(input, target, weight torch.Tensor, ignoreIndex int64,
+	reduction string) (torch.Tensor, error) {
+	r := C.CString(reduction)
+	defer C.free(unsafe.Pointer(r))
+	var t C.Tensor
+	result, err := tensorOrError("CrossEntropy", C.CrossEntropy(
 		C.Tensor(*input.T),
 		C.Tensor(*target.T),
-		cweight,
+		optional(weight),
 		C.int64_t(ignoreIndex),
-		C.CString(reduction),
-		&t)))
+		r,
+		&t), &t, input, target, weight)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return result, err
 }
 
 // Relu torch.nn.functional.relu
 func Relu(input torch.Tensor, inplace bool) torch.Tensor {
+	return torch.Must(TryRelu(input, inplace))
+}
+
+// TryRelu is Relu that returns an error rather than panics
+func TryRelu(input torch.Tensor, inplace bool) (torch.Tensor, error) {
 	var t C.Tensor
-	var cInplace C.int8_t
-	if inplace {
-		cInplace = 1
-	}
-	torch.MustNil(unsafe.Pointer(C.FRelu(
-		C.Tensor(*input.T), C.int8_t(cInplace), &t)))
+	r, err := tensorOrError("Relu", C.FRelu(C.Tensor(*input.T),
+		C.int8_t(torch.BoolToInt8(inplace)), &t), &t, input)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
 
 // LeakyRelu torch.nn.functional.leaky_relu
 func LeakyRelu(input torch.Tensor, negativeSlope float64, inplace bool) torch.Tensor {
+	return torch.Must(TryLeakyRelu(input, negativeSlope, inplace))
+}
+
+// TryLeakyRelu is LeakyRelu that returns an error rather than panics
+func TryLeakyRelu(input torch.Tensor, negativeSlope float64,
+	inplace bool) (torch.Tensor, error) {
 	var t C.Tensor
-	var cInplace C.int8_t
-	if inplace {
-		cInplace = 1
-	}
-	torch.MustNil(unsafe.Pointer(C.FLeakyRelu(C.Tensor(*input.T),
-		C.double(negativeSlope), C.int8_t(cInplace), &t)))
+	r, err := tensorOrError("LeakyRelu", C.FLeakyRelu(C.Tensor(*input.T),
+		C.double(negativeSlope), C.int8_t(torch.BoolToInt8(inplace)), &t), &t, input)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
 
 // Linear ports torch.nn.functional.linear
 func Linear(input, weight, bias torch.Tensor) torch.Tensor {
+	return torch.Must(TryLinear(input, weight, bias))
+}
+
+// TryLinear is Linear that returns an error rather than panics
+func TryLinear(input, weight, bias torch.Tensor) (torch.Tensor, error) {
 	var t C.Tensor
-	var cBias C.Tensor
-	if bias.T != nil {
-		cBias = C.Tensor(*bias.T)
-	}
-	torch.MustNil(unsafe.Pointer(C.Linear(
-		C.Tensor(*input.T),
-		C.Tensor(*weight.T), cBias, &t)))
+	r, err := tensorOrError("Linear", C.Linear(C.Tensor(*input.T),
+		C.Tensor(*weight.T), optional(bias), &t), &t, input, weight, bias)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
 
 // MaxPool2d torch.nn.functional.max_pool2d
 func MaxPool2d(input torch.Tensor, kernelSize, stride, padding,
 	dilation []int64, ceilMode bool) torch.Tensor {
-	var cMode C.int8_t
-	if ceilMode {
-		cMode = 1
-	}
+	return torch.Must(TryMaxPool2d(input, kernelSize, stride, padding,
+		dilation, ceilMode))
+}
+
+// TryMaxPool2d is MaxPool2d that returns an error rather than panics
+func TryMaxPool2d(input torch.Tensor, kernelSize, stride, padding,
+	dilation []int64, ceilMode bool) (torch.Tensor, error) {
 	var t C.Tensor
-	torch.MustNil(unsafe.Pointer(C.MaxPool2d(
+	r, err := tensorOrError("MaxPool2d", C.MaxPool2d(
 		C.Tensor(*input.T),
-		(*C.int64_t)(unsafe.Pointer(&kernelSize[0])), C.int64_t(len(kernelSize)),
-		(*C.int64_t)(unsafe.Pointer(&stride[0])), C.int64_t(len(stride)),
-		(*C.int64_t)(unsafe.Pointer(&padding[0])), C.int64_t(len(padding)),
-		(*C.int64_t)(unsafe.Pointer(&dilation[0])), C.int64_t(len(dilation)),
-		cMode,
-		&t)))
+		dims(kernelSize), C.int64_t(len(kernelSize)),
+		dims(stride), C.int64_t(len(stride)),
+		dims(padding), C.int64_t(len(padding)),
+		dims(dilation), C.int64_t(len(dilation)),
+		C.int8_t(torch.BoolToInt8(ceilMode)),
+		&t), &t, input)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
 
 // AdaptiveAvgPool2d torch.nn.functional.adaptive_avg_pool2d
 func AdaptiveAvgPool2d(input torch.Tensor, outputSize []int64) torch.Tensor {
+	return torch.Must(TryAdaptiveAvgPool2d(input, outputSize))
+}
+
+// TryAdaptiveAvgPool2d is AdaptiveAvgPool2d that returns an error rather than
+// panics
+func TryAdaptiveAvgPool2d(input torch.Tensor, outputSize []int64) (torch.Tensor, error) {
 	var t C.Tensor
-	torch.MustNil(unsafe.Pointer(C.AdaptiveAvgPool2d(
-		C.Tensor(*input.T),
-		(*C.int64_t)(unsafe.Pointer(&outputSize[0])), C.int64_t(len(outputSize)),
-		&t)))
+	r, err := tensorOrError("AdaptiveAvgPool2d", C.AdaptiveAvgPool2d(
+		C.Tensor(*input.T), dims(outputSize), C.int64_t(len(outputSize)), &t),
+		&t, input)
 	runtime.KeepAlive(input.T)
-	torch.SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return torch.Tensor{(*unsafe.Pointer)(&t)}
+	return r, err
 }
@@ -155,3 +155,32 @@ func TestFunctionalCrossEntropy(t *testing.T) {
 	o := CrossEntropy(input, target, weight, -100, "mean")
 	assert.Equal(t, "2.36302\n[ CPUDoubleType{} ]", o.String())
 }
+
+func TestFunctionalTryLinear(t *testing.T) {
+	a := assert.New(t)
+	input := torch.RandN([]int64{32, 10}, false)
+	weight := torch.RandN([]int64{5, 20}, false)
+	_, err := TryLinear(input, weight, torch.Tensor{})
+	a.Error(err)
+	e, ok := err.(*torch.TorchError)
+	a.True(ok)
+	a.Equal("Linear", e.Op)
+	a.Equal([]int64{32, 10}, e.Shapes[0])
+	a.Nil(e.Shapes[2]) // The undefined bias.
+	a.Equal(torch.Invalid, e.Dtypes[2])
+}
+
+func TestFunctionalTryNllLoss(t *testing.T) {
+	a := assert.New(t)
+	input := torch.RandN([]int64{3, 5}, false)
+	target := torch.NewTensor([]int64{1, 0, 4})
+	weight := torch.RandN([]int64{4}, false)
+	_, err := TryNllLoss(input, target, weight, -100, "mean")
+	a.Error(err)
+	e, ok := err.(*torch.TorchError)
+	a.True(ok)
+	a.Equal([]int64{4}, e.Shapes[2]) // The weight is reported.
+
+	_, err = TryMaxPool2d(input, nil, nil, nil, nil, false)
+	a.Error(err)
+}
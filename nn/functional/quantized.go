@@ -0,0 +1,133 @@
+package functional
+
+// #cgo CFLAGS: -I ${SRCDIR}/../..
+// #cgo LDFLAGS: -L ${SRCDIR}/../../cgotorch -Wl,-rpath ${SRCDIR}/../../cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/../../cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/../../cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+// FBGEMMIsCPUSupported returns true if FBGEMM, the library running
+// DynamicQuantizedLinear, supports the CPU, which requires AVX2.
+func FBGEMMIsCPUSupported() bool {
+	return C.FBGEMM_IsCPUSupported() != 0
+}
+
+// QuantizeLinearWeight quantizes the Float weight of a linear layer into a
+// QInt8 tensor quantized per tensor for DynamicQuantizedLinear.
+func QuantizeLinearWeight(weight torch.Tensor) torch.Tensor {
+	return torch.Must(TryQuantizeLinearWeight(weight))
+}
+
+// TryQuantizeLinearWeight is QuantizeLinearWeight that returns an error
+// rather than panics
+func TryQuantizeLinearWeight(weight torch.Tensor) (torch.Tensor, error) {
+	var t C.Tensor
+	r, err := tensorOrError("QuantizeLinearWeight",
+		C.QuantizeLinearWeight(C.Tensor(*weight.T), &t), &t, weight)
+	runtime.KeepAlive(weight.T)
+	return r, err
+}
+
+// PackedLinearWeight caches the QInt8 weight of a linear layer in the layout
+// of FBGEMM, so DynamicQuantizedLinear doesn't pack the weight in each call.
+// The zero value is an empty cache.  It is safe for concurrent use.
+type PackedLinearWeight struct {
+	mu     sync.Mutex
+	weight torch.Tensor
+	key    packedKey
+	packed *packedLinear
+}
+
+// packedKey identifies the elements of a packed weight.  The cache keeps the
+// packed weight alive, so no other tensor could reuse its data pointer.
+type packedKey struct {
+	data    uintptr
+	version int64
+}
+
+func packedKeyOf(weight torch.Tensor) packedKey {
+	var data unsafe.Pointer
+	var version C.int64_t
+	C.PackedWeightKey(C.Tensor(*weight.T), &data, &version)
+	return packedKey{uintptr(data), int64(version)}
+}
+
+// packedLinear is the immutable result of packing a weight.
+type packedLinear struct {
+	intWeight  torch.Tensor
+	packed     torch.Tensor
+	colOffsets torch.Tensor
+	scale      float64
+	zeroPoint  int64
+}
+
+// get returns weight packed.  It packs weight again if the elements of weight
+// are not those packed last time, e.g., after Module.SetStateDict, Module.To,
+// or an in-place update like SetData or CopyI.
+func (c *PackedLinearWeight) get(weight torch.Tensor) (*packedLinear, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := packedKeyOf(weight)
+	if c.packed != nil && c.key == key {
+		return c.packed, nil
+	}
+	var intWeight, packed, colOffsets C.Tensor
+	err := torch.CheckNil("PackLinearWeight", unsafe.Pointer(C.PackLinearWeight(
+		C.Tensor(*weight.T), &intWeight, &packed, &colOffsets)), weight)
+	if err != nil {
+		return nil, err
+	}
+	torch.SetTensorFinalizer((*unsafe.Pointer)(&intWeight))
+	torch.SetTensorFinalizer((*unsafe.Pointer)(&packed))
+	torch.SetTensorFinalizer((*unsafe.Pointer)(&colOffsets))
+	c.weight = weight
+	c.key = key
+	// The cache outlives the scope, if any, calling DynamicQuantizedLinear.
+	c.packed = &packedLinear{
+		intWeight:  torch.Keep(torch.Tensor{T: (*unsafe.Pointer)(&intWeight)}),
+		packed:     torch.Keep(torch.Tensor{T: (*unsafe.Pointer)(&packed)}),
+		colOffsets: torch.Keep(torch.Tensor{T: (*unsafe.Pointer)(&colOffsets)}),
+		scale:      weight.QScale(),
+		zeroPoint:  weight.QZeroPoint(),
+	}
+	return c.packed, nil
+}
+
+// DynamicQuantizedLinear is Linear with the QInt8 weight returned by
+// QuantizeLinearWeight, like torch.nn.quantized.dynamic.Linear.  It
+// quantizes input to 8-bit integers on the fly and runs the matrix
+// multiplication in int8 on CPU.  bias is a Float tensor or Tensor{}.  cache
+// keeps the packed weight across calls, or nil to pack in each call.
+func DynamicQuantizedLinear(input, weight, bias torch.Tensor,
+	cache *PackedLinearWeight) torch.Tensor {
+	return torch.Must(TryDynamicQuantizedLinear(input, weight, bias, cache))
+}
+
+// TryDynamicQuantizedLinear is DynamicQuantizedLinear that returns an error
+// rather than panics
+func TryDynamicQuantizedLinear(input, weight, bias torch.Tensor,
+	cache *PackedLinearWeight) (torch.Tensor, error) {
+	if cache == nil {
+		cache = &PackedLinearWeight{}
+	}
+	p, err := cache.get(weight)
+	if err != nil {
+		return torch.Tensor{}, err
+	}
+	var t C.Tensor
+	r, err := tensorOrError("DynamicQuantizedLinear",
+		C.DynamicQuantizedLinear(C.Tensor(*input.T), C.Tensor(*p.intWeight.T),
+			C.Tensor(*p.packed.T), C.Tensor(*p.colOffsets.T), C.double(p.scale),
+			C.int64_t(p.zeroPoint), optional(bias), &t), &t, input, weight, bias)
+	runtime.KeepAlive(input.T)
+	runtime.KeepAlive(p)
+	return r, err
+}
@@ -13,6 +13,16 @@ import (
 	torch "github.com/wangkuiyi/gotorch"
 )
 
+// Random initializers accept the option "generator", a torch.Generator.
+// Without the option, they use the default generator.
+func generator(op string, opt []map[string]interface{}) C.Generator {
+	g, err := torch.GeneratorOption(op, opt)
+	if err != nil {
+		panic(err)
+	}
+	return C.Generator(g)
+}
+
 // ManualSeed set the random seed
 func ManualSeed(seed int64) {
 	C.ManualSeed(C.int64_t(seed))
@@ -35,29 +45,31 @@ func Ones(a *torch.Tensor) {
 }
 
 // Uniform initialization, torch.nn.init.uniform_
-func Uniform(a *torch.Tensor, low, high float64) {
+func Uniform(a *torch.Tensor, low, high float64, opt ...map[string]interface{}) {
 	if a == nil || a.T == nil {
 		log.Panicf("Normal: input tensor is nil")
 	}
-	torch.MustNil(unsafe.Pointer(C.Uniform_((*C.Tensor)(a.T), C.double(low), C.double(high))))
+	torch.MustNil(unsafe.Pointer(C.Uniform_((*C.Tensor)(a.T), C.double(low), C.double(high),
+		generator("Uniform", opt))))
 }
 
 // Normal initialization, torch.nn.init.normal_
-func Normal(a *torch.Tensor, mean, std float64) {
+func Normal(a *torch.Tensor, mean, std float64, opt ...map[string]interface{}) {
 	if a == nil || a.T == nil {
 		log.Panicf("Normal: input tensor is nil")
 	}
-	torch.MustNil(unsafe.Pointer(C.Normal_((*C.Tensor)(a.T), C.double(mean), C.double(std))))
+	torch.MustNil(unsafe.Pointer(C.Normal_((*C.Tensor)(a.T), C.double(mean), C.double(std),
+		generator("Normal", opt))))
 }
 
 // KaimingUniform initialization, torch.nn.init.kaiming_uniform_
 func KaimingUniform(input *torch.Tensor, a float64, fanMode string,
-	nonLinearity string) {
+	nonLinearity string, opt ...map[string]interface{}) {
 	if input == nil || input.T == nil {
 		log.Panicf("Normal: input tensor is nil")
 	}
 	torch.MustNil(unsafe.Pointer(C.KaimingUniform_(C.double(a), C.CString(fanMode),
-		C.CString(nonLinearity), (*C.Tensor)(input.T))))
+		C.CString(nonLinearity), (*C.Tensor)(input.T), generator("KaimingUniform", opt))))
 }
 
 // CalculateFanInAndFanOut torch.nn.init._calculate_fan_in_and_fan_out
@@ -88,3 +88,27 @@ func forAllElements(t *testing.T, x torch.Tensor, ck func(elem interface{}) bool
 		}
 	}
 }
+
+func TestInitializerGenerator(t *testing.T) {
+	g := torch.NewGenerator(3)
+	opt := map[string]interface{}{"generator": g}
+	x := torch.Empty([]int64{4, 5}, false)
+	y := torch.Empty([]int64{4, 5}, false)
+
+	Normal(&x, 0, 1, opt)
+	g.ManualSeed(3)
+	Normal(&y, 0, 1, opt)
+	assert.True(t, torch.Equal(x, y))
+
+	g.ManualSeed(3)
+	Uniform(&x, -1, 1, opt)
+	g.ManualSeed(3)
+	Uniform(&y, -1, 1, opt)
+	assert.True(t, torch.Equal(x, y))
+
+	g.ManualSeed(3)
+	KaimingUniform(&x, 0, "fan_in", "relu", opt)
+	g.ManualSeed(3)
+	KaimingUniform(&y, 0, "fan_in", "relu", opt)
+	assert.True(t, torch.Equal(x, y))
+}
@@ -15,6 +15,9 @@ type LinearModule struct {
 	OutFeatures int64
 	Weight      torch.Tensor
 	Bias        torch.Tensor
+	// Packed caches the QInt8 Weight of a module converted by
+	// QuantizeDynamic, or nil for modules with Float weights.
+	Packed *F.PackedLinearWeight
 }
 
 // Linear creates a `Linear` instance
@@ -35,6 +38,9 @@ func Linear(in, out int64, bias bool) *LinearModule {
 
 // Forward does a linear transformation to the `input` tensor.
 func (l *LinearModule) Forward(x torch.Tensor) torch.Tensor {
+	if l.Packed != nil {
+		return F.DynamicQuantizedLinear(x, l.Weight, l.Bias, l.Packed)
+	}
 	return F.Linear(x, l.Weight, l.Bias)
 }
 
@@ -8,7 +8,11 @@ import (
 	torch "github.com/wangkuiyi/gotorch"
 )
 
-// IModule is the interface of `Module`s
+// IModule is the interface of `Module`s.  The Forward methods of modules in
+// the evaluation mode only read parameters and buffers, so goroutines could
+// call them concurrently, e.g., in handlers of an HTTP server, as long as no
+// goroutine calls Train, To, or SetStateDict, or updates the parameters at
+// the same time.  See doc/concurrency.md for details.
 type IModule interface {
 	// Train corresponds to torch.nn.Module.train(bool). It effects only
 	// certain modules like Dropout and BatchNorm.
@@ -17,7 +21,7 @@ type IModule interface {
 	IsTraining() bool
 	// To corresponds to torch.nn.Module.to().  It recursively casts all
 	// parameters to the given `dtype` and `device`.
-	To(device torch.Device, dtype ...int8)
+	To(device torch.Device, dtype ...torch.Dtype)
 	// StateDict mimics torch.nn.Module.state_dict()
 	StateDict() map[string]torch.Tensor
 	// SetStateDict mimics torch.nn.Module.set_state_dict()
@@ -121,25 +125,24 @@ func (m *Module) IsTraining() bool {
 }
 
 // To recursively casts all parameters to the given `dtype` and `device`.
-func (m *Module) To(device torch.Device, dtype ...int8) {
+func (m *Module) To(device torch.Device, dtype ...torch.Dtype) {
 	must(m.outer != nil, "GoTorch requires calling `Init` before using")
-	// Each call to Tensor.To generates a new Go Tensor instance.  We don't
-	// have to recycle the old tensors explicitly, but leaving the work to
-	// Go GC.  However, by actively triggering the GC, the we can recycle
-	// old tensors in time and keep the program memory footprint small.
-	torch.GC()
-	defer torch.FinishGC()
+	// Each call to Tensor.To generates a new Go Tensor instance.  We close
+	// it right after SetData, rather than triggering the Go GC, to keep the
+	// memory footprint small, so To works in any goroutine.
 	visitTensors(m.outer, reflect.TypeOf(m.outer).Elem().Name(),
 		func(f reflect.StructField, v reflect.Value, prefix string, noSuffix bool) error {
 			t := v.Interface().(torch.Tensor)
 			if t.T != nil {
-				var d int8
+				var d torch.Dtype
 				if len(dtype) == 1 {
 					d = dtype[0]
 				} else {
 					d = t.Dtype()
 				}
-				t.SetData(t.To(device, d))
+				n := t.To(device, d)
+				t.SetData(n)
+				n.Close()
 			}
 			return nil
 		})
@@ -0,0 +1,39 @@
+package nn
+
+import (
+	torch "github.com/wangkuiyi/gotorch"
+	F "github.com/wangkuiyi/gotorch/nn/functional"
+)
+
+// QuantizeDynamic converts every LinearModule in the module tree m for
+// dynamically quantized inference on CPU, like
+// torch.quantization.quantize_dynamic with dtype torch.qint8.  It replaces
+// the weights of the modules by QInt8 tensors, which take a quarter of the
+// memory, and their Forward methods call F.DynamicQuantizedLinear, which
+// runs the matrix multiplications in int8.  The biases stay Float.
+//
+// The converted modules are for inference only, because the weights don't
+// require gradients.  The state dict of a converted model has the QInt8
+// weights, so to load it, call QuantizeDynamic on a new model before
+// SetStateDict.  QuantizeDynamic requires the CPU to support FBGEMM, see
+// F.FBGEMMIsCPUSupported.
+func QuantizeDynamic(m IModule) {
+	m.Apply(func(n IModule) {
+		if l, ok := n.(*Module).Outer().(*LinearModule); ok {
+			l.quantizeDynamic()
+		}
+	})
+}
+
+func (l *LinearModule) quantizeDynamic() {
+	if l.Packed != nil {
+		return
+	}
+	if l.Weight.Dtype() != torch.QInt8 {
+		l.Weight = F.QuantizeLinearWeight(l.Weight.Detach())
+	}
+	if l.Bias.T != nil {
+		l.Bias = l.Bias.Detach()
+	}
+	l.Packed = &F.PackedLinearWeight{}
+}
@@ -0,0 +1,82 @@
+package nn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+	F "github.com/wangkuiyi/gotorch/nn/functional"
+)
+
+func maxAbsDiff(a, b torch.Tensor) float64 {
+	x, y := a.Float32s(), b.Float32s()
+	d := 0.0
+	for i := range x {
+		d = math.Max(d, math.Abs(float64(x[i]-y[i])))
+	}
+	return d
+}
+
+func TestQuantizeDynamic(t *testing.T) {
+	if !F.FBGEMMIsCPUSupported() {
+		t.Skip("FBGEMM doesn't support the CPU")
+	}
+	a := assert.New(t)
+	m := Sequential(Linear(32, 16, true), Functional(torch.Relu),
+		Linear(16, 4, false))
+	x := torch.RandN([]int64{8, 32}, false)
+	y := m.Forward(x).(torch.Tensor)
+
+	QuantizeDynamic(m)
+	l := m.Modules[0].(*LinearModule)
+	a.Equal(torch.QInt8, l.Weight.Dtype())
+	a.Equal(torch.Float, l.Bias.Dtype())
+	a.False(l.Bias.RequiresGrad())
+	yq := m.Forward(x).(torch.Tensor)
+	a.Equal(y.Shape(), yq.Shape())
+	a.True(maxAbsDiff(y, yq) < 0.1)
+
+	// Converting twice changes nothing
+	w := l.Weight
+	QuantizeDynamic(m)
+	a.Equal(*w.T, *l.Weight.T)
+
+	// Load the state dict into a quantized model
+	n := Sequential(Linear(32, 16, true), Functional(torch.Relu),
+		Linear(16, 4, false))
+	QuantizeDynamic(n)
+	a.NoError(n.SetStateDict(m.StateDict()))
+	a.True(torch.Equal(yq, n.Forward(x).(torch.Tensor)))
+
+	// Updating the weight in-place repacks it
+	h := torch.RandN([]int64{8, 16}, false)
+	nl := n.Modules[2].(*LinearModule)
+	before := nl.Forward(h)
+	w2 := F.QuantizeLinearWeight(torch.RandN([]int64{4, 16}, false))
+	nl.Weight.SetData(w2)
+	after := nl.Forward(h)
+	a.False(torch.Equal(before, after))
+	a.True(torch.Equal(F.DynamicQuantizedLinear(h, w2, torch.Tensor{}, nil), after))
+}
+
+func benchmarkLinear(b *testing.B, quantize bool) {
+	l := Linear(1024, 1024, true)
+	if quantize {
+		if !F.FBGEMMIsCPUSupported() {
+			b.Skip("FBGEMM doesn't support the CPU")
+		}
+		QuantizeDynamic(l)
+	}
+	x := torch.RandN([]int64{16, 1024}, false)
+	b.ResetTimer()
+	torch.NoGrad(func() {
+		for i := 0; i < b.N; i++ {
+			l.Forward(x).Close()
+		}
+	})
+}
+
+func BenchmarkLinear(b *testing.B) { benchmarkLinear(b, false) }
+
+func BenchmarkDynamicQuantizedLinear(b *testing.B) { benchmarkLinear(b, true) }
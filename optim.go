@@ -7,7 +7,6 @@ package gotorch
 import "C"
 import (
 	"runtime"
-	"unsafe"
 )
 
 // Optimizer struct
@@ -36,12 +35,8 @@ func Adam(lr, beta1, beta2, weightDecay float64) Optimizer {
 
 // AddParameters adds parameters
 func (opt Optimizer) AddParameters(tensors []Tensor) {
-	CT := []C.Tensor{}
-	for _, t := range tensors {
-		CT = append(CT, C.Tensor(*t.T))
-	}
-	p := (*C.Tensor)(unsafe.Pointer(&CT[0]))
-	C.Optimizer_AddParameters(*opt.Opt, p, C.int64_t(len(CT)))
+	CT := cTensors(tensors)
+	C.Optimizer_AddParameters(*opt.Opt, &CT[0], C.int64_t(len(tensors)))
 }
 
 // ZeroGrad reset gradients to zero
@@ -0,0 +1,251 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"github.com/wangkuiyi/gotorch/variadic"
+)
+
+// Generator wraps a pointer to C.Generator, a CPU random number generator,
+// torch.Generator.  Functions that sample random numbers accept the option
+// "generator", so data augmentation, dropout, and initialization could have
+// independent and reproducible random streams.  For example,
+//
+//	g := torch.NewGenerator(42)
+//	x := torch.RandN([]int64{2, 3}, false, map[string]interface{}{"generator": g})
+//
+// Without the option, these functions use the default generator, whose seed
+// is set by initializer.ManualSeed.
+type Generator struct {
+	G *unsafe.Pointer
+}
+
+func newGenerator(g C.Generator) Generator {
+	p := (*unsafe.Pointer)(unsafe.Pointer(&g))
+	runtime.SetFinalizer(p, func(p *unsafe.Pointer) {
+		C.Generator_Close(C.Generator(*p))
+	})
+	return Generator{p}
+}
+
+// NewGenerator returns a generator seeded by seed
+func NewGenerator(seed int64) Generator {
+	g, err := TryNewGenerator(seed)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// TryNewGenerator is NewGenerator that returns an error rather than panics
+func TryNewGenerator(seed int64) (Generator, error) {
+	var g C.Generator
+	if err := CheckNil("NewGenerator",
+		unsafe.Pointer(C.NewGenerator(C.int64_t(seed), &g))); err != nil {
+		return Generator{}, err
+	}
+	return newGenerator(g), nil
+}
+
+// DefaultGenerator returns the default CPU generator
+func DefaultGenerator() Generator {
+	return newGenerator(C.DefaultGenerator())
+}
+
+// ManualSeed sets the seed of g
+func (g Generator) ManualSeed(seed int64) {
+	C.Generator_ManualSeed(C.Generator(*g.G), C.int64_t(seed))
+}
+
+// Seed returns the seed set by NewGenerator or the last ManualSeed, like
+// torch.Generator.initial_seed.  Sampling doesn't change it.
+func (g Generator) Seed() int64 {
+	return int64(C.Generator_Seed(C.Generator(*g.G)))
+}
+
+// StateBytes returns the state of g, which SetStateBytes accepts.  The state
+// is valid only for the same build of GoTorch.
+func (g Generator) StateBytes() []byte {
+	state := make([]byte, int(C.Generator_StateSize()))
+	MustNil(unsafe.Pointer(C.Generator_GetState(C.Generator(*g.G),
+		unsafe.Pointer(&state[0]))))
+	return state
+}
+
+// SetStateBytes restores the state of g returned by StateBytes
+func (g Generator) SetStateBytes(state []byte) {
+	if err := g.TrySetStateBytes(state); err != nil {
+		panic(err)
+	}
+}
+
+// TrySetStateBytes is SetStateBytes that returns an error rather than panics
+func (g Generator) TrySetStateBytes(state []byte) error {
+	if len(state) == 0 {
+		return newTorchError("SetState", "empty generator state")
+	}
+	return CheckNil("SetState", unsafe.Pointer(C.Generator_SetState(
+		C.Generator(*g.G), unsafe.Pointer(&state[0]), C.int64_t(len(state)))))
+}
+
+// GetState returns the state of g as a Byte tensor, which SetState accepts,
+// torch.Generator.get_state
+func (g Generator) GetState() Tensor {
+	return NewTensor(g.StateBytes())
+}
+
+// SetState restores the state of g returned by GetState,
+// torch.Generator.set_state
+func (g Generator) SetState(state Tensor) {
+	if err := g.TrySetState(state); err != nil {
+		panic(err)
+	}
+}
+
+// TrySetState is SetState that returns an error rather than panics
+func (g Generator) TrySetState(state Tensor) error {
+	if state.Dtype() != Byte || state.Dim() != 1 {
+		return newTorchError("SetState",
+			"the generator state must be a one-dimensional Byte tensor", state)
+	}
+	if err := g.TrySetStateBytes(state.Uint8s()); err != nil {
+		err.(*TorchError).Shapes = [][]int64{state.Shape()}
+		err.(*TorchError).Dtypes = []Dtype{state.Dtype()}
+		return err
+	}
+	return nil
+}
+
+// GeneratorOption returns the C generator in the option "generator", or nil
+// for the default generator.  It returns the *TorchError of op if the option
+// isn't a Generator.  Packages like nn/initializer convert the result to
+// their C.Generator.
+func GeneratorOption(op string, opt []map[string]interface{}) (unsafe.Pointer, error) {
+	v, ok := variadic.Lookup(opt, "generator")
+	if !ok {
+		return nil, nil
+	}
+	g, ok := v.(Generator)
+	if !ok || g.G == nil {
+		return nil, newTorchError(op, fmt.Sprintf(
+			"the option generator must be a torch.Generator, got %T", v))
+	}
+	return *g.G, nil
+}
+
+func generatorOption(op string, opt []map[string]interface{}) (C.Generator, error) {
+	g, err := GeneratorOption(op, opt)
+	return C.Generator(g), err
+}
+
+// RandInt torch.randint returns a Long tensor of integers uniformly sampled
+// from [low, high)
+func RandInt(low, high int64, shape []int64, opt ...map[string]interface{}) Tensor {
+	return Must(TryRandInt(low, high, shape, opt...))
+}
+
+// TryRandInt is RandInt that returns an error rather than panics
+func TryRandInt(low, high int64, shape []int64,
+	opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("RandInt", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("RandInt", C.RandInt(C.int64_t(low), C.int64_t(high),
+		dimsPtr(shape), C.int64_t(len(shape)), g, &t), &t)
+}
+
+// RandPerm torch.randperm returns a random permutation of [0, n)
+func RandPerm(n int64, opt ...map[string]interface{}) Tensor {
+	return Must(TryRandPerm(n, opt...))
+}
+
+// TryRandPerm is RandPerm that returns an error rather than panics
+func TryRandPerm(n int64, opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("RandPerm", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("RandPerm", C.RandPerm(C.int64_t(n), g, &t), &t)
+}
+
+// Bernoulli torch.bernoulli draws 0 or 1 for each element of probs with the
+// element as the probability of 1
+func Bernoulli(probs Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryBernoulli(probs, opt...))
+}
+
+// TryBernoulli is Bernoulli that returns an error rather than panics
+func TryBernoulli(probs Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("Bernoulli", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("Bernoulli",
+		C.Bernoulli(C.Tensor(*probs.T), g, &t), &t, probs)
+}
+
+// Multinomial torch.multinomial samples numSamples indices from each row of
+// probs, the unnormalized probabilities
+func Multinomial(probs Tensor, numSamples int64, replacement bool,
+	opt ...map[string]interface{}) Tensor {
+	return Must(TryMultinomial(probs, numSamples, replacement, opt...))
+}
+
+// TryMultinomial is Multinomial that returns an error rather than panics
+func TryMultinomial(probs Tensor, numSamples int64, replacement bool,
+	opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("Multinomial", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("Multinomial", C.Multinomial(C.Tensor(*probs.T),
+		C.int64_t(numSamples), boolToInt8(replacement), g, &t), &t, probs)
+}
+
+// Normal torch.normal(mean, std, size) returns a tensor of the given shape
+// filled with samples of the normal distribution
+func Normal(mean, std float64, shape []int64, opt ...map[string]interface{}) Tensor {
+	return Must(TryNormal(mean, std, shape, opt...))
+}
+
+// TryNormal is Normal that returns an error rather than panics
+func TryNormal(mean, std float64, shape []int64,
+	opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("Normal", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("Normal", C.Normal(C.double(mean), C.double(std),
+		dimsPtr(shape), C.int64_t(len(shape)), g, &t), &t)
+}
+
+// NormalTensor torch.normal(mean, std) samples each element from the normal
+// distribution with the corresponding elements of mean and std
+func NormalTensor(mean, std Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryNormalTensor(mean, std, opt...))
+}
+
+// TryNormalTensor is NormalTensor that returns an error rather than panics
+func TryNormalTensor(mean, std Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("NormalTensor", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("NormalTensor", C.NormalTensor(C.Tensor(*mean.T),
+		C.Tensor(*std.T), g, &t), &t, mean, std)
+}
@@ -0,0 +1,98 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestGenerator(t *testing.T) {
+	g := torch.NewGenerator(42)
+	assert.Equal(t, int64(42), g.Seed())
+	opt := map[string]interface{}{"generator": g}
+	a := torch.RandN([]int64{2, 3}, false, opt)
+
+	g.ManualSeed(42)
+	b := torch.RandN([]int64{2, 3}, false, opt)
+	assert.True(t, torch.Equal(a, b))
+
+	// Independent of the default generator.
+	g.ManualSeed(42)
+	torch.RandN([]int64{10}, false)
+	c := torch.RandN([]int64{2, 3}, false, opt)
+	assert.True(t, torch.Equal(a, c))
+}
+
+func TestGeneratorState(t *testing.T) {
+	g := torch.NewGenerator(1)
+	opt := map[string]interface{}{"generator": g}
+	torch.RandN([]int64{3}, false, opt)
+	s := g.GetState()
+	a := torch.RandN([]int64{5}, false, opt)
+	b := torch.Rand([]int64{5}, false, opt)
+	g.SetState(s)
+	assert.True(t, torch.Equal(a, torch.RandN([]int64{5}, false, opt)))
+	assert.True(t, torch.Equal(b, torch.Rand([]int64{5}, false, opt)))
+
+	assert.Equal(t, torch.Byte, s.Dtype())
+	assert.Panics(t, func() { g.SetState(s.Narrow(0, 1, s.Shape()[0]-1)) })
+	assert.Panics(t, func() { g.SetState(torch.Ones([]int64{3}, false)) })
+	assert.NotEmpty(t, torch.DefaultGenerator().GetState().Shape())
+
+	// Sampling doesn't change the seed.
+	assert.Equal(t, int64(1), g.Seed())
+}
+
+func TestGeneratorStateBytes(t *testing.T) {
+	g := torch.NewGenerator(3)
+	opt := map[string]interface{}{"generator": g}
+	torch.Normal(0, 1, []int64{3}, opt)
+	s := g.StateBytes()
+	a := torch.Normal(0, 1, []int64{5}, opt)
+	g.SetStateBytes(s)
+	assert.True(t, torch.Equal(a, torch.Normal(0, 1, []int64{5}, opt)))
+
+	err := g.TrySetStateBytes(s[1:])
+	assert.Equal(t, "SetState", err.(*torch.TorchError).Op)
+	assert.Error(t, g.TrySetStateBytes(nil))
+	assert.Error(t, g.TrySetState(torch.Ones([]int64{3}, false)))
+}
+
+func TestGeneratorOption(t *testing.T) {
+	opt := map[string]interface{}{"generator": 42}
+	_, err := torch.TryRandInt(0, 2, []int64{3}, opt)
+	assert.Equal(t, "RandInt", err.(*torch.TorchError).Op)
+	assert.Contains(t, err.Error(), "generator")
+	_, err = torch.TryRandN([]int64{3}, false, opt)
+	assert.Equal(t, "RandN", err.(*torch.TorchError).Op)
+	assert.Panics(t, func() { torch.RandPerm(3, opt) })
+
+	_, err = torch.TryMultinomial(torch.NewTensor([]float32{0, 1}), 3, false)
+	assert.Equal(t, "Multinomial", err.(*torch.TorchError).Op)
+}
+
+func TestSampling(t *testing.T) {
+	opt := map[string]interface{}{"generator": torch.NewGenerator(7)}
+
+	x := torch.RandInt(3, 5, []int64{100}, opt)
+	assert.Equal(t, torch.Long, x.Dtype())
+	for _, v := range x.Int64s() {
+		assert.True(t, v >= 3 && v < 5)
+	}
+
+	p := torch.RandPerm(5, opt)
+	v, _ := p.Sort(0, false, false)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4}, v.Int64s())
+
+	b := torch.Bernoulli(torch.NewTensor([]float32{0, 1, 0, 1}), opt)
+	assert.Equal(t, []float32{0, 1, 0, 1}, b.Float32s())
+
+	m := torch.Multinomial(torch.NewTensor([]float32{0, 0, 1}), 2, true, opt)
+	assert.Equal(t, []int64{2, 2}, m.Int64s())
+
+	n := torch.Normal(5, 0, []int64{2, 2}, opt)
+	assert.Equal(t, []float32{5, 5, 5, 5}, n.Float32s())
+	n = torch.NormalTensor(torch.NewTensor([]float32{1, 2}), torch.NewTensor([]float32{0, 0}), opt)
+	assert.Equal(t, []float32{1, 2}, n.Float32s())
+}
@@ -9,6 +9,7 @@ package gotorch
 import "C"
 
 import (
+	"fmt"
 	"log"
 	"unsafe"
 )
@@ -18,12 +19,13 @@ type Tensor struct {
 	T *unsafe.Pointer
 }
 
-// MustNil asserts error to be nil
+// MustNil asserts error to be nil.  Otherwise, it panics with a *TorchError,
+// which could be recovered by Try.
 func MustNil(err unsafe.Pointer) {
 	if err != nil {
 		msg := C.GoString((*C.char)(err))
 		C.FreeString((*C.char)(err))
-		panic(msg)
+		panic(&TorchError{Message: msg})
 	}
 }
 
@@ -79,8 +81,8 @@ func (a Tensor) Shape() []int64 {
 }
 
 // Dtype returns data type
-func (a Tensor) Dtype() int8 {
-	var t int8
+func (a Tensor) Dtype() Dtype {
+	var t Dtype
 	MustNil(unsafe.Pointer(C.Tensor_Dtype(C.Tensor(*a.T), (*C.int8_t)(unsafe.Pointer(&t)))))
 	return t
 }
@@ -90,26 +92,37 @@ func (a Tensor) Backward() {
 	C.Tensor_Backward(C.Tensor(*a.T))
 }
 
-// Grad returns a reference of the gradient
+// Grad returns a reference of the gradient.  The gradient is undefined before
+// the first backward pass reaches a; check it with Defined.
 func (a Tensor) Grad() Tensor {
 	t := C.Tensor_Grad(C.Tensor(*a.T))
 	SetTensorFinalizer((*unsafe.Pointer)(&t))
 	return Tensor{(*unsafe.Pointer)(&t)}
 }
 
+// Defined returns if a refers to a C++ tensor that holds data, like
+// at::Tensor::defined().
+func (a Tensor) Defined() bool {
+	return a.T != nil && *a.T != nil && C.Tensor_Defined(C.Tensor(*a.T)) != 0
+}
+
 // To returns a Tensor on the specified device with the same content as the a.
 // If the specified device doesn't exist, To panics.
-func (a Tensor) To(device Device, dtype ...int8) Tensor {
+func (a Tensor) To(device Device, dtype ...Dtype) Tensor {
+	return Must(a.TryTo(device, dtype...))
+}
+
+// TryTo is To that returns an error rather than panics
+func (a Tensor) TryTo(device Device, dtype ...Dtype) (Tensor, error) {
 	var t C.Tensor
-	var d int8
+	var d Dtype
 	if len(dtype) == 0 {
 		d = a.Dtype()
 	} else {
 		d = dtype[0]
 	}
-	MustNil(unsafe.Pointer(C.Tensor_To(C.Tensor(*a.T), device.T, C.int8_t(d), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("To",
+		C.Tensor_To(C.Tensor(*a.T), device.T, C.int8_t(d), &t), &t, a)
 }
 
 // CUDA returns a Tensor on CUDA device
@@ -125,7 +138,7 @@ func (a Tensor) CUDA(device Device, nonBlocking bool) Tensor {
 }
 
 // CastTo cast tensor dtype
-func (a Tensor) CastTo(dtype int8) Tensor {
+func (a Tensor) CastTo(dtype Dtype) Tensor {
 	var t C.Tensor
 	MustNil(unsafe.Pointer(C.Tensor_CastTo(C.Tensor(*a.T), C.int8_t(dtype), &t)))
 	SetTensorFinalizer((*unsafe.Pointer)(&t))
@@ -158,21 +171,20 @@ func (a Tensor) SetData(b Tensor) {
 
 // To returns a Tensor on the specified device with the same content as the a.
 // If the specified device doesn't exist, To panics.
-func To(a Tensor, device Device, dtype int8) Tensor {
+func To(a Tensor, device Device, dtype Dtype) Tensor {
 	return a.To(device, dtype)
 }
 
 // FromBlob returns a deep copy Tensor with the given data memory
-func FromBlob(data unsafe.Pointer, dtype int8, sizes []int64) Tensor {
+func FromBlob(data unsafe.Pointer, dtype Dtype, sizes []int64) Tensor {
+	return Must(TryFromBlob(data, dtype, sizes))
+}
+
+// TryFromBlob is FromBlob that returns an error rather than panics
+func TryFromBlob(data unsafe.Pointer, dtype Dtype, sizes []int64) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Tensor_FromBlob(
-		data,
-		C.int8_t(dtype),
-		(*C.int64_t)(unsafe.Pointer(&sizes[0])),
-		C.int64_t(len(sizes)),
-		&t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("FromBlob", C.Tensor_FromBlob(data, C.int8_t(dtype),
+		dimsPtr(sizes), C.int64_t(len(sizes)), &t), &t)
 }
 
 // Index calls Tensor::index to return a single-element tensor of the element at
@@ -181,11 +193,21 @@ func (a Tensor) Index(index ...int64) Tensor {
 	if int64(len(index)) != a.Dim() {
 		log.Panicf("Index %v has length that differs from the tenosr dim %d", index, a.Dim())
 	}
+	return Must(a.TryIndex(index...))
+}
+
+// TryIndex is Index that returns an error rather than panics
+func (a Tensor) TryIndex(index ...int64) (Tensor, error) {
+	if int64(len(index)) != a.Dim() {
+		return Tensor{}, &TorchError{
+			Op:      "Index",
+			Shapes:  [][]int64{a.Shape()},
+			Dtypes:  []Dtype{a.Dtype()},
+			Message: fmt.Sprintf("index %v has length that differs from the tensor dim %d", index, a.Dim()),
+		}
+	}
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Tensor_Index(
+	return newTensorOrError("Index", C.Tensor_Index(
 		C.Tensor(*a.T),
-		(*C.int64_t)(unsafe.Pointer(&index[0])),
-		C.int64_t(len(index)), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+		dimsPtr(index), C.int64_t(len(index)), &t), &t, a)
 }
@@ -0,0 +1,99 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include <stdlib.h>
+// #include "cgotorch/cgotorch.h"
+//
+// extern void goBlobDeleter(int64_t handle);
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// FromBlob copies the data, whereas FromBuffer and FromFile return tensors
+// sharing the memory with the data.  They save the time and memory to copy
+// large minibatches and embedding tables.
+
+var (
+	deletersMu sync.Mutex
+	deleters   = make(map[int64]func())
+	nextBlob   int64
+)
+
+func init() {
+	C.Tensor_SetBlobDeleter((C.BlobDeleter)(C.goBlobDeleter))
+}
+
+// FromBuffer returns a tensor of the given dtype and shape over data without
+// copying it.  libtorch calls deleter when it frees the storage, i.e., after
+// all tensors sharing the storage are freed, so deleter could release the
+// memory, e.g., by calling C.free or gocv.Mat.Close.  data must point to
+// memory allocated outside the Go heap, e.g., by C.malloc, OpenCV, or
+// syscall.Mmap, because the cgo rules forbid C code from keeping Go pointers
+// after the call returns.  Use NewTensor or FromBlob, which copy, for Go
+// slices.  For example,
+//
+//	p := C.malloc(32 * 32 * 4)
+//	x := torch.FromBuffer(p, torch.Float, []int64{32, 32},
+//		func() { C.free(p) })
+//
+// The tensor and data share the elements, so writes to either are visible to
+// the other.  deleter might run in any goroutine or OS thread.
+func FromBuffer(data unsafe.Pointer, dtype Dtype, shape []int64, deleter func()) Tensor {
+	deletersMu.Lock()
+	nextBlob++
+	handle := nextBlob
+	deleters[handle] = deleter
+	deletersMu.Unlock()
+
+	var t C.Tensor
+	err := C.Tensor_FromBlobNoCopy(data, C.int8_t(dtype), dimsPtr(shape),
+		C.int64_t(len(shape)), C.int64_t(handle), &t)
+	if err != nil {
+		deletersMu.Lock()
+		delete(deleters, handle)
+		deletersMu.Unlock()
+	}
+	MustNil(unsafe.Pointer(err))
+	SetTensorFinalizer((*unsafe.Pointer)(&t))
+	return Tensor{(*unsafe.Pointer)(&t)}
+}
+
+//export goBlobDeleter
+func goBlobDeleter(handle C.int64_t) {
+	deletersMu.Lock()
+	f := deleters[int64(handle)]
+	delete(deleters, int64(handle))
+	deletersMu.Unlock()
+	if f != nil {
+		f()
+	}
+}
+
+// FromFile maps a file of raw little-endian elements of dtype into memory and
+// returns a tensor of shape over the elements, or a 1-dimensional tensor of
+// all elements in the file if shape is nil.  A non-nil empty shape gives a
+// 0-dimensional tensor of the first element.  The option "offset" skips the
+// header of the file, and must be a multiple of the element size.  FromFile
+// returns instantly for large files, because the operating system reads the
+// pages of the file on demand.  Processes mapping the same file share the
+// pages in memory, unless they write to the tensor, which copies the written
+// pages and never changes the file.
+func FromFile(path string, dtype Dtype, shape []int64, opt ...map[string]interface{}) Tensor {
+	return Must(TryFromFile(path, dtype, shape, opt...))
+}
+
+// TryFromFile is FromFile that returns an error rather than panics
+func TryFromFile(path string, dtype Dtype, shape []int64,
+	opt ...map[string]interface{}) (Tensor, error) {
+	p := C.CString(path)
+	defer C.free(unsafe.Pointer(p))
+	var t C.Tensor
+	return newTensorOrError("FromFile", C.Tensor_FromFile(p, C.int8_t(dtype),
+		boolToInt8(shape != nil), dimsPtr(shape), C.int64_t(len(shape)),
+		C.int64_t(int64Option(opt, "offset", 0)), &t), &t)
+}
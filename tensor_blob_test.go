@@ -0,0 +1,96 @@
+package gotorch_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestFromBuffer(t *testing.T) {
+	a := assert.New(t)
+	// FromBuffer requires memory outside the Go heap.
+	mem, e := syscall.Mmap(-1, 0, 6*4, syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	a.NoError(e)
+	data := (*[6]float32)(unsafe.Pointer(&mem[0]))[:]
+	copy(data, []float32{1, 2, 3, 4, 5, 6})
+	deleted := false
+	x := torch.FromBuffer(unsafe.Pointer(&mem[0]), torch.Float,
+		[]int64{2, 3}, func() { deleted = syscall.Munmap(mem) == nil })
+	a.Equal([]int64{2, 3}, x.Shape())
+	a.Equal(torch.Float, x.Dtype())
+	a.Equal([]float32{1, 2, 3, 4, 5, 6}, x.Float32s())
+
+	// x shares the elements with data
+	data[0] = 10
+	a.Equal(float32(10), x.Float32s()[0])
+	x.MulScalarI(2)
+	a.Equal(float32(4), data[1])
+
+	a.False(deleted)
+	x.Close()
+	a.True(deleted)
+}
+
+func writeFloat32s(t *testing.T, header []byte, v []float32) string {
+	var buf bytes.Buffer
+	buf.Write(header)
+	assert.NoError(t, binary.Write(&buf, binary.LittleEndian, v))
+	dir, e := ioutil.TempDir("", "gotorch_blob")
+	assert.NoError(t, e)
+	path := filepath.Join(dir, "data.bin")
+	assert.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+	return path
+}
+
+func TestFromFile(t *testing.T) {
+	a := assert.New(t)
+	v := []float32{1, 2, 3, 4, 5, 6}
+	path := writeFloat32s(t, nil, v)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	x := torch.FromFile(path, torch.Float, nil)
+	a.Equal([]int64{6}, x.Shape())
+	a.Equal(v, x.Float32s())
+
+	s := torch.FromFile(path, torch.Float, []int64{})
+	a.Equal(0, len(s.Shape()))
+	a.Equal(float32(1), s.Item())
+
+	y := torch.FromFile(path, torch.Float, []int64{3, 2})
+	a.Equal([]int64{3, 2}, y.Shape())
+	a.Equal(v, y.Float32s())
+
+	// Writes to the tensor don't change the file.
+	y.MulScalarI(0)
+	a.Equal(v, torch.FromFile(path, torch.Float, nil).Float32s())
+
+	_, e := torch.TryFromFile(path, torch.Float, []int64{7})
+	a.Error(e)
+	_, e = torch.TryFromFile(filepath.Join(filepath.Dir(path), "missing"),
+		torch.Float, nil)
+	a.Error(e)
+}
+
+func TestFromFileOffset(t *testing.T) {
+	a := assert.New(t)
+	v := []float32{1, 2, 3, 4}
+	path := writeFloat32s(t, []byte{'G', 'T', 'C', 'H', 0, 0, 0, 0}, v)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	x := torch.FromFile(path, torch.Float, []int64{2, 2},
+		map[string]interface{}{"offset": int64(8)})
+	a.Equal(v, x.Float32s())
+
+	_, e := torch.TryFromFile(path, torch.Float, nil,
+		map[string]interface{}{"offset": int64(3)})
+	a.Error(e)
+}
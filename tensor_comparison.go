@@ -0,0 +1,414 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+// Comparisons, logical operations, and IsNaN, IsInf and IsFinite return Bool
+// tensors, which work as masks for MaskedFill, MaskedSelect and Where, and as
+// conditions for Any and All.  Binary operations broadcast their operands.
+// Each operation Xxx panics if libtorch reports an error, and its counterpart
+// TryXxx returns the error.
+
+// Gt torch.gt, a > other
+func Gt(a, other Tensor) Tensor {
+	return Must(TryGt(a, other))
+}
+
+// TryGt is Gt that returns an error rather than panics
+func TryGt(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Gt",
+		C.Gt(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// Gt torch.gt
+func (a Tensor) Gt(other Tensor) Tensor {
+	return Gt(a, other)
+}
+
+// Ge torch.ge, a >= other
+func Ge(a, other Tensor) Tensor {
+	return Must(TryGe(a, other))
+}
+
+// TryGe is Ge that returns an error rather than panics
+func TryGe(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Ge",
+		C.Ge(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// Ge torch.ge
+func (a Tensor) Ge(other Tensor) Tensor {
+	return Ge(a, other)
+}
+
+// Lt torch.lt, a < other
+func Lt(a, other Tensor) Tensor {
+	return Must(TryLt(a, other))
+}
+
+// TryLt is Lt that returns an error rather than panics
+func TryLt(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Lt",
+		C.Lt(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// Lt torch.lt
+func (a Tensor) Lt(other Tensor) Tensor {
+	return Lt(a, other)
+}
+
+// Le torch.le, a <= other
+func Le(a, other Tensor) Tensor {
+	return Must(TryLe(a, other))
+}
+
+// TryLe is Le that returns an error rather than panics
+func TryLe(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Le",
+		C.Le(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// Le torch.le
+func (a Tensor) Le(other Tensor) Tensor {
+	return Le(a, other)
+}
+
+// Ne torch.ne, a != other
+func Ne(a, other Tensor) Tensor {
+	return Must(TryNe(a, other))
+}
+
+// TryNe is Ne that returns an error rather than panics
+func TryNe(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Ne",
+		C.Ne(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// Ne torch.ne
+func (a Tensor) Ne(other Tensor) Tensor {
+	return Ne(a, other)
+}
+
+// EqScalar torch.eq with a scalar operand
+func EqScalar(a Tensor, other float64) Tensor {
+	return a.EqScalar(other)
+}
+
+// EqScalar torch.eq with a scalar operand
+func (a Tensor) EqScalar(other float64) Tensor {
+	return Must(TryEqScalar(a, other))
+}
+
+// TryEqScalar is EqScalar that returns an error rather than panics
+func TryEqScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("EqScalar",
+		C.EqScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// GtScalar torch.gt with a scalar operand
+func GtScalar(a Tensor, other float64) Tensor {
+	return a.GtScalar(other)
+}
+
+// GtScalar torch.gt with a scalar operand
+func (a Tensor) GtScalar(other float64) Tensor {
+	return Must(TryGtScalar(a, other))
+}
+
+// TryGtScalar is GtScalar that returns an error rather than panics
+func TryGtScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("GtScalar",
+		C.GtScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// GeScalar torch.ge with a scalar operand
+func GeScalar(a Tensor, other float64) Tensor {
+	return a.GeScalar(other)
+}
+
+// GeScalar torch.ge with a scalar operand
+func (a Tensor) GeScalar(other float64) Tensor {
+	return Must(TryGeScalar(a, other))
+}
+
+// TryGeScalar is GeScalar that returns an error rather than panics
+func TryGeScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("GeScalar",
+		C.GeScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// LtScalar torch.lt with a scalar operand
+func LtScalar(a Tensor, other float64) Tensor {
+	return a.LtScalar(other)
+}
+
+// LtScalar torch.lt with a scalar operand
+func (a Tensor) LtScalar(other float64) Tensor {
+	return Must(TryLtScalar(a, other))
+}
+
+// TryLtScalar is LtScalar that returns an error rather than panics
+func TryLtScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LtScalar",
+		C.LtScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// LeScalar torch.le with a scalar operand
+func LeScalar(a Tensor, other float64) Tensor {
+	return a.LeScalar(other)
+}
+
+// LeScalar torch.le with a scalar operand
+func (a Tensor) LeScalar(other float64) Tensor {
+	return Must(TryLeScalar(a, other))
+}
+
+// TryLeScalar is LeScalar that returns an error rather than panics
+func TryLeScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LeScalar",
+		C.LeScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// NeScalar torch.ne with a scalar operand
+func NeScalar(a Tensor, other float64) Tensor {
+	return a.NeScalar(other)
+}
+
+// NeScalar torch.ne with a scalar operand
+func (a Tensor) NeScalar(other float64) Tensor {
+	return Must(TryNeScalar(a, other))
+}
+
+// TryNeScalar is NeScalar that returns an error rather than panics
+func TryNeScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("NeScalar",
+		C.NeScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// LogicalAnd torch.logical_and, which treats non-zero elements as true
+func LogicalAnd(a, other Tensor) Tensor {
+	return Must(TryLogicalAnd(a, other))
+}
+
+// TryLogicalAnd is LogicalAnd that returns an error rather than panics
+func TryLogicalAnd(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LogicalAnd",
+		C.LogicalAnd(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// LogicalAnd torch.logical_and
+func (a Tensor) LogicalAnd(other Tensor) Tensor {
+	return LogicalAnd(a, other)
+}
+
+// LogicalOr torch.logical_or, which treats non-zero elements as true
+func LogicalOr(a, other Tensor) Tensor {
+	return Must(TryLogicalOr(a, other))
+}
+
+// TryLogicalOr is LogicalOr that returns an error rather than panics
+func TryLogicalOr(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LogicalOr",
+		C.LogicalOr(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// LogicalOr torch.logical_or
+func (a Tensor) LogicalOr(other Tensor) Tensor {
+	return LogicalOr(a, other)
+}
+
+// LogicalXor torch.logical_xor, which treats non-zero elements as true
+func LogicalXor(a, other Tensor) Tensor {
+	return Must(TryLogicalXor(a, other))
+}
+
+// TryLogicalXor is LogicalXor that returns an error rather than panics
+func TryLogicalXor(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LogicalXor",
+		C.LogicalXor(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// LogicalXor torch.logical_xor
+func (a Tensor) LogicalXor(other Tensor) Tensor {
+	return LogicalXor(a, other)
+}
+
+// LogicalNot torch.logical_not
+func LogicalNot(a Tensor) Tensor {
+	return a.LogicalNot()
+}
+
+// LogicalNot torch.logical_not
+func (a Tensor) LogicalNot() Tensor {
+	return Must(TryLogicalNot(a))
+}
+
+// TryLogicalNot is LogicalNot that returns an error rather than panics
+func TryLogicalNot(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LogicalNot",
+		C.LogicalNot(C.Tensor(*a.T), &t), &t, a)
+}
+
+// IsNaN torch.isnan
+func IsNaN(a Tensor) Tensor {
+	return a.IsNaN()
+}
+
+// IsNaN torch.isnan
+func (a Tensor) IsNaN() Tensor {
+	return Must(TryIsNaN(a))
+}
+
+// TryIsNaN is IsNaN that returns an error rather than panics
+func TryIsNaN(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("IsNaN",
+		C.IsNaN(C.Tensor(*a.T), &t), &t, a)
+}
+
+// IsInf torch.isinf
+func IsInf(a Tensor) Tensor {
+	return a.IsInf()
+}
+
+// IsInf torch.isinf
+func (a Tensor) IsInf() Tensor {
+	return Must(TryIsInf(a))
+}
+
+// TryIsInf is IsInf that returns an error rather than panics
+func TryIsInf(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("IsInf",
+		C.IsInf(C.Tensor(*a.T), &t), &t, a)
+}
+
+// IsFinite torch.isfinite
+func IsFinite(a Tensor) Tensor {
+	return a.IsFinite()
+}
+
+// IsFinite torch.isfinite
+func (a Tensor) IsFinite() Tensor {
+	return Must(TryIsFinite(a))
+}
+
+// TryIsFinite is IsFinite that returns an error rather than panics
+func TryIsFinite(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("IsFinite",
+		C.IsFinite(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Where torch.where, which takes elements from a where condition is true, and
+// from b otherwise
+func Where(condition, a, b Tensor) Tensor {
+	return Must(TryWhere(condition, a, b))
+}
+
+// TryWhere is Where that returns an error rather than panics
+func TryWhere(condition, a, b Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Where",
+		C.Where(C.Tensor(*condition.T), C.Tensor(*a.T), C.Tensor(*b.T), &t),
+		&t, condition, a, b)
+}
+
+// MaskedFill torch.masked_fill, which replaces elements of a with value where
+// mask is true
+func MaskedFill(a, mask Tensor, value float64) Tensor {
+	return Must(TryMaskedFill(a, mask, value))
+}
+
+// TryMaskedFill is MaskedFill that returns an error rather than panics
+func TryMaskedFill(a, mask Tensor, value float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("MaskedFill",
+		C.MaskedFill(C.Tensor(*a.T), C.Tensor(*mask.T), C.double(value), &t),
+		&t, a, mask)
+}
+
+// MaskedFill torch.Tensor.masked_fill
+func (a Tensor) MaskedFill(mask Tensor, value float64) Tensor {
+	return MaskedFill(a, mask, value)
+}
+
+// MaskedSelect torch.masked_select, which returns a 1-D tensor of elements of
+// a where mask is true
+func MaskedSelect(a, mask Tensor) Tensor {
+	return Must(TryMaskedSelect(a, mask))
+}
+
+// TryMaskedSelect is MaskedSelect that returns an error rather than panics
+func TryMaskedSelect(a, mask Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("MaskedSelect",
+		C.MaskedSelect(C.Tensor(*a.T), C.Tensor(*mask.T), &t), &t, a, mask)
+}
+
+// MaskedSelect torch.Tensor.masked_select
+func (a Tensor) MaskedSelect(mask Tensor) Tensor {
+	return MaskedSelect(a, mask)
+}
+
+// Nonzero torch.nonzero, which returns a Long tensor of shape [n, a.Dim()]
+// holding the indices of the n non-zero elements
+func Nonzero(a Tensor) Tensor {
+	return a.Nonzero()
+}
+
+// Nonzero torch.nonzero
+func (a Tensor) Nonzero() Tensor {
+	return Must(TryNonzero(a))
+}
+
+// TryNonzero is Nonzero that returns an error rather than panics
+func TryNonzero(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Nonzero",
+		C.Nonzero(C.Tensor(*a.T), &t), &t, a)
+}
+
+// ClampTensor torch.clamp with tensor bounds, which broadcast to a.  The
+// result has the shape of a, and bounds that would enlarge it are errors.
+// Either min or max could be Tensor{} for no bound.
+func ClampTensor(a, min, max Tensor) Tensor {
+	return Must(TryClampTensor(a, min, max))
+}
+
+// TryClampTensor is ClampTensor that returns an error rather than panics
+func TryClampTensor(a, min, max Tensor) (Tensor, error) {
+	var lo, hi C.Tensor
+	if min.T != nil {
+		lo = C.Tensor(*min.T)
+	}
+	if max.T != nil {
+		hi = C.Tensor(*max.T)
+	}
+	var t C.Tensor
+	return newTensorOrError("ClampTensor",
+		C.ClampTensor(C.Tensor(*a.T), lo, hi, &t), &t, a, min, max)
+}
+
+// ClampTensor torch.clamp with tensor bounds
+func (a Tensor) ClampTensor(min, max Tensor) Tensor {
+	return ClampTensor(a, min, max)
+}
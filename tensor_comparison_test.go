@@ -0,0 +1,88 @@
+package gotorch_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestComparison(t *testing.T) {
+	a := torch.NewTensor([]float32{1, 2, 3})
+	b := torch.NewTensor([]float32{3, 2, 1})
+	assert.Equal(t, []bool{false, false, true}, torch.Gt(a, b).Bools())
+	assert.Equal(t, []bool{false, true, true}, a.Ge(b).Bools())
+	assert.Equal(t, []bool{true, false, false}, a.Lt(b).Bools())
+	assert.Equal(t, []bool{true, true, false}, a.Le(b).Bools())
+	assert.Equal(t, []bool{true, false, true}, a.Ne(b).Bools())
+	assert.Equal(t, torch.Bool, a.Gt(b).Dtype())
+
+	assert.Equal(t, []bool{false, true, false}, a.EqScalar(2).Bools())
+	assert.Equal(t, []bool{false, false, true}, torch.GtScalar(a, 2).Bools())
+	assert.Equal(t, []bool{false, true, true}, a.GeScalar(2).Bools())
+	assert.Equal(t, []bool{true, false, false}, a.LtScalar(2).Bools())
+	assert.Equal(t, []bool{true, true, false}, a.LeScalar(2).Bools())
+	assert.Equal(t, []bool{true, false, true}, a.NeScalar(2).Bools())
+
+	_, e := torch.TryGt(a, torch.NewTensor([]float32{1, 2}))
+	assert.Error(t, e)
+}
+
+func TestLogical(t *testing.T) {
+	a := torch.NewTensor([]bool{true, true, false, false})
+	b := torch.NewTensor([]bool{true, false, true, false})
+	assert.Equal(t, []bool{true, false, false, false}, torch.LogicalAnd(a, b).Bools())
+	assert.Equal(t, []bool{true, true, true, false}, a.LogicalOr(b).Bools())
+	assert.Equal(t, []bool{false, true, true, false}, a.LogicalXor(b).Bools())
+	assert.Equal(t, []bool{false, false, true, true}, a.LogicalNot().Bools())
+}
+
+func TestIsNaNIsInfIsFinite(t *testing.T) {
+	a := torch.NewTensor([]float64{1, math.NaN(), math.Inf(1), math.Inf(-1)})
+	assert.Equal(t, []bool{false, true, false, false}, a.IsNaN().Bools())
+	assert.Equal(t, []bool{false, false, true, true}, torch.IsInf(a).Bools())
+	assert.Equal(t, []bool{true, false, false, false}, a.IsFinite().Bools())
+}
+
+func TestWhereAndMasked(t *testing.T) {
+	a := torch.NewTensor([][]float32{{1, -2}, {-3, 4}})
+	mask := a.GtScalar(0)
+	assert.Equal(t, []float32{1, 0, 0, 4},
+		torch.Where(mask, a, torch.NewTensor([]float32{0})).Float32s())
+	assert.Equal(t, []float32{1, 0, 0, 4}, a.MaskedFill(mask.LogicalNot(), 0).Float32s())
+	assert.Equal(t, []float32{1, 4}, a.MaskedSelect(mask).Float32s())
+	assert.Equal(t, []int64{0, 0, 1, 1}, mask.Nonzero().Int64s())
+	assert.Equal(t, []int64{2, 2}, mask.Nonzero().Shape())
+
+	_, e := torch.TryMaskedSelect(a, torch.NewTensor([]bool{true, false, true}))
+	assert.Error(t, e)
+}
+
+func TestClampTensor(t *testing.T) {
+	a := torch.NewTensor([]float32{-2, 0, 2})
+	lo := torch.NewTensor([]float32{-1, 1, -1})
+	hi := torch.NewTensor([]float32{1})
+	assert.Equal(t, []float32{-1, 1, 1}, torch.ClampTensor(a, lo, hi).Float32s())
+	assert.Equal(t, []float32{-1, 1, 2}, a.ClampTensor(lo, torch.Tensor{}).Float32s())
+	assert.Equal(t, []float32{-2, 0, 1}, a.ClampTensor(torch.Tensor{}, hi).Float32s())
+
+	// Bounds must not enlarge a.
+	_, err := torch.TryClampTensor(a, torch.NewTensor([][]float32{{0}, {1}}), torch.Tensor{})
+	assert.Equal(t, "ClampTensor", err.(*torch.TorchError).Op)
+	assert.Panics(t, func() { a.ClampTensor(torch.Tensor{}, torch.NewTensor([]float32{0, 1})) })
+}
+
+func TestTryComparison(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, float32(math.NaN())})
+	m, e := torch.TryIsNaN(x)
+	a.NoError(e)
+	a.Equal([]bool{false, true}, m.Bools())
+	m, e = torch.TryLogicalNot(m)
+	a.NoError(e)
+	a.Equal([]bool{true, false}, m.Bools())
+
+	_, e = torch.TryGt(x, torch.NewTensor([]float32{1, 2, 3}))
+	a.Error(e)
+}
@@ -0,0 +1,110 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+// Real torch.real returns the real parts of a complex tensor as a
+// real-valued tensor, like Abs does
+func Real(a Tensor) Tensor {
+	return Must(TryReal(a))
+}
+
+// TryReal is Real that returns an error rather than panics
+func TryReal(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Real", C.Real(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Real torch.real
+func (a Tensor) Real() Tensor {
+	return Real(a)
+}
+
+// Imag torch.imag returns the imaginary parts of a complex tensor as a
+// real-valued tensor
+func Imag(a Tensor) Tensor {
+	return Must(TryImag(a))
+}
+
+// TryImag is Imag that returns an error rather than panics
+func TryImag(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Imag", C.Imag(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Imag torch.imag
+func (a Tensor) Imag() Tensor {
+	return Imag(a)
+}
+
+// Angle torch.angle returns the arguments of the elements in radians as a
+// real-valued tensor
+func Angle(a Tensor) Tensor {
+	return Must(TryAngle(a))
+}
+
+// TryAngle is Angle that returns an error rather than panics
+func TryAngle(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Angle", C.Angle(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Angle torch.angle
+func (a Tensor) Angle() Tensor {
+	return Angle(a)
+}
+
+// Conj torch.conj returns the complex conjugates of the elements
+func Conj(a Tensor) Tensor {
+	return Must(TryConj(a))
+}
+
+// TryConj is Conj that returns an error rather than panics
+func TryConj(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Conj", C.Conj(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Conj torch.conj
+func (a Tensor) Conj() Tensor {
+	return Conj(a)
+}
+
+// ViewAsReal torch.view_as_real returns a real-valued view of a complex
+// tensor with an extra last dimension of size 2 for the real and imaginary
+// parts
+func ViewAsReal(a Tensor) Tensor {
+	return Must(TryViewAsReal(a))
+}
+
+// TryViewAsReal is ViewAsReal that returns an error rather than panics
+func TryViewAsReal(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ViewAsReal", C.ViewAsReal(C.Tensor(*a.T), &t), &t, a)
+}
+
+// ViewAsReal torch.view_as_real
+func (a Tensor) ViewAsReal() Tensor {
+	return ViewAsReal(a)
+}
+
+// ViewAsComplex torch.view_as_complex returns a complex view of a Float or
+// Double tensor whose last dimension of size 2 holds the real and imaginary
+// parts
+func ViewAsComplex(a Tensor) Tensor {
+	return Must(TryViewAsComplex(a))
+}
+
+// TryViewAsComplex is ViewAsComplex that returns an error rather than panics
+func TryViewAsComplex(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ViewAsComplex", C.ViewAsComplex(C.Tensor(*a.T), &t), &t, a)
+}
+
+// ViewAsComplex torch.view_as_complex
+func (a Tensor) ViewAsComplex() Tensor {
+	return ViewAsComplex(a)
+}
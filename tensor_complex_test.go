@@ -0,0 +1,52 @@
+package gotorch_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestComplexTensor(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]complex64{{1 + 2i, 3 - 4i}, {0, -1i}})
+	a.Equal(torch.ComplexFloat, x.Dtype())
+	a.Equal([]int64{2, 2}, x.Shape())
+	a.Equal([]complex64{1 + 2i, 3 - 4i, 0, -1i}, x.Complex64s())
+	a.Equal([][]complex64{{1 + 2i, 3 - 4i}, {0, -1i}}, x.ToSlice())
+
+	a.Equal([]float32{1, 3, 0, 0}, x.Real().Float32s())
+	a.Equal([]float32{2, -4, 0, -1}, x.Imag().Float32s())
+	a.Equal([]complex64{1 - 2i, 3 + 4i, 0, 1i}, x.Conj().Complex64s())
+	abs := x.Abs()
+	a.Equal(torch.Float, abs.Dtype())
+	a.InDeltaSlice([]float32{float32(math.Sqrt(5)), 5, 0, 1}, abs.Float32s(), 1e-6)
+
+	y := torch.NewTensor([]complex128{1i, -1})
+	a.Equal(torch.ComplexDouble, y.Dtype())
+	a.InDeltaSlice([]float64{math.Pi / 2, math.Pi}, y.Angle().Float64s(), 1e-12)
+	a.Equal(complex128(-1), y.Index(1).Item())
+	a.Equal(complex64(3-4i), x.Index(0, 1).Item())
+
+	r, err := torch.TryReal(y)
+	a.NoError(err)
+	a.Equal([]float64{0, -1}, r.Float64s())
+	_, err = torch.TryAngle(torch.NewTensor([]bool{true}))
+	a.Error(err)
+	a.Equal("Angle", err.(*torch.TorchError).Op)
+}
+
+func TestViewAsRealAndComplex(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]complex64{1 + 2i, 3 - 4i})
+	r := x.ViewAsReal()
+	a.Equal([]int64{2, 2}, r.Shape())
+	a.Equal([]float32{1, 2, 3, -4}, r.Float32s())
+	a.Equal([]complex64{1 + 2i, 3 - 4i}, r.ViewAsComplex().Complex64s())
+
+	_, e := torch.TryViewAsComplex(torch.NewTensor([]float32{1, 2, 3}))
+	a.Error(e)
+	_, e = torch.TryImag(torch.NewTensor([]float32{1, 2}))
+	a.Error(e)
+}
@@ -6,114 +6,113 @@ package gotorch
 // #include "cgotorch/cgotorch.h"
 import "C"
 
-import (
-	"unsafe"
-)
-
-// RandN returns a tensor filled with standard normal distribution, torch.randn
-func RandN(shape []int64, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
+// Constructors panic if libtorch reports an error, e.g., for a negative size,
+// and their Try counterparts return the error.
+
+// RandN returns a tensor filled with standard normal distribution, torch.randn.
+// It accepts the option "generator".
+func RandN(shape []int64, requiresGrad bool, opt ...map[string]interface{}) Tensor {
+	return Must(TryRandN(shape, requiresGrad, opt...))
+}
+
+// TryRandN is RandN that returns an error rather than panics
+func TryRandN(shape []int64, requiresGrad bool, opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("RandN", opt)
+	if err != nil {
+		return Tensor{}, err
 	}
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.RandN((*C.int64_t)(unsafe.Pointer(&shape[0])),
-		C.int64_t(len(shape)), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("RandN", C.RandN(dimsPtr(shape), C.int64_t(len(shape)),
+		C.int64_t(boolToInt8(requiresGrad)), g, &t), &t)
 }
 
-// Rand torch.rand
-func Rand(shape []int64, requireGrad bool) Tensor {
-	rg := 0
-	if requireGrad {
-		rg = 1
+// Rand torch.rand.  It accepts the option "generator".
+func Rand(shape []int64, requireGrad bool, opt ...map[string]interface{}) Tensor {
+	return Must(TryRand(shape, requireGrad, opt...))
+}
+
+// TryRand is Rand that returns an error rather than panics
+func TryRand(shape []int64, requireGrad bool, opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("Rand", opt)
+	if err != nil {
+		return Tensor{}, err
 	}
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Rand((*C.int64_t)(unsafe.Pointer(&shape[0])),
-		C.int64_t(len(shape)), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Rand", C.Rand(dimsPtr(shape), C.int64_t(len(shape)),
+		C.int64_t(boolToInt8(requireGrad)), g, &t), &t)
 }
 
 // Empty returns a tensor filled with random number, torch.empty
 func Empty(shape []int64, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
-	}
+	return Must(TryEmpty(shape, requiresGrad))
+}
+
+// TryEmpty is Empty that returns an error rather than panics
+func TryEmpty(shape []int64, requiresGrad bool) (Tensor, error) {
 	var t C.Tensor
-	MustNil(
-		unsafe.Pointer(C.Empty((*C.int64_t)(unsafe.Pointer(&shape[0])),
-			C.int64_t(len(shape)), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Empty", C.Empty(dimsPtr(shape), C.int64_t(len(shape)),
+		C.int64_t(boolToInt8(requiresGrad)), &t), &t)
 }
 
 // Ones return a tensor filled with 1
 func Ones(shape []int64, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
-	}
+	return Must(TryOnes(shape, requiresGrad))
+}
+
+// TryOnes is Ones that returns an error rather than panics
+func TryOnes(shape []int64, requiresGrad bool) (Tensor, error) {
 	var t C.Tensor
-	MustNil(
-		unsafe.Pointer(C.Ones((*C.int64_t)(unsafe.Pointer(&shape[0])),
-			C.int64_t(len(shape)), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Ones", C.Ones(dimsPtr(shape), C.int64_t(len(shape)),
+		C.int64_t(boolToInt8(requiresGrad)), &t), &t)
 }
 
 // Eye returns a tensor with 1s on diagonal and 0s elsewhere
 func Eye(n, m int64, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
-	}
+	return Must(TryEye(n, m, requiresGrad))
+}
+
+// TryEye is Eye that returns an error rather than panics
+func TryEye(n, m int64, requiresGrad bool) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Eye(C.int64_t(n), C.int64_t(m), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Eye", C.Eye(C.int64_t(n), C.int64_t(m),
+		C.int64_t(boolToInt8(requiresGrad)), &t), &t)
 }
 
 // Full returns a tensor with all elements being given `value`
 func Full(shape []int64, value float32, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
-	}
+	return Must(TryFull(shape, value, requiresGrad))
+}
+
+// TryFull is Full that returns an error rather than panics
+func TryFull(shape []int64, value float32, requiresGrad bool) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Full((*C.int64_t)(unsafe.Pointer(&shape[0])),
-		C.int64_t(len(shape)), C.float(value), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Full", C.Full(dimsPtr(shape), C.int64_t(len(shape)),
+		C.float(value), C.int64_t(boolToInt8(requiresGrad)), &t), &t)
 }
 
 // Arange returns a 1-D tensor in range [begin, end) with
 // common difference step beginning from begin
 func Arange(begin, end, step float32, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
-	}
+	return Must(TryArange(begin, end, step, requiresGrad))
+}
+
+// TryArange is Arange that returns an error rather than panics
+func TryArange(begin, end, step float32, requiresGrad bool) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Arange(C.float(begin),
-		C.float(end), C.float(step), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Arange", C.Arange(C.float(begin), C.float(end),
+		C.float(step), C.int64_t(boolToInt8(requiresGrad)), &t), &t)
 }
 
 // Linspace returns a 1-D Tensor in range [begin, end] with steps points
 func Linspace(begin, end float32, steps int64, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
-	}
+	return Must(TryLinspace(begin, end, steps, requiresGrad))
+}
+
+// TryLinspace is Linspace that returns an error rather than panics
+func TryLinspace(begin, end float32, steps int64, requiresGrad bool) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Linspace(C.float(begin),
-		C.float(end), C.int64_t(steps), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Linspace", C.Linspace(C.float(begin), C.float(end),
+		C.int64_t(steps), C.int64_t(boolToInt8(requiresGrad)), &t), &t)
 }
 
 // Logspace returns a 1-D Tensor of steps points
@@ -121,14 +120,13 @@ func Linspace(begin, end float32, steps int64, requiresGrad bool) Tensor {
 // pow(base, begin) and pow(base, end)
 func Logspace(begin, end float32, steps int64,
 	base float64, requiresGrad bool) Tensor {
-	rg := 0
-	if requiresGrad {
-		rg = 1
-	}
+	return Must(TryLogspace(begin, end, steps, base, requiresGrad))
+}
+
+// TryLogspace is Logspace that returns an error rather than panics
+func TryLogspace(begin, end float32, steps int64,
+	base float64, requiresGrad bool) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Logspace(C.float(begin),
-		C.float(end), C.int64_t(steps),
-		C.double(base), C.int64_t(rg), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Logspace", C.Logspace(C.float(begin), C.float(end),
+		C.int64_t(steps), C.double(base), C.int64_t(boolToInt8(requiresGrad)), &t), &t)
 }
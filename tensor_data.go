@@ -0,0 +1,217 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"log"
+	"reflect"
+	"unsafe"
+
+	"github.com/x448/float16"
+)
+
+// The following methods copy the elements of a tensor into a flat Go slice in
+// the row-major order.  If the tensor is on GPU or is not contiguous, they
+// copy it into a contiguous CPU buffer first.  If the dtype of the tensor
+// differs from the Go element type, they cast the elements like CastTo does.
+
+// Bools returns the elements as a []bool
+func (a Tensor) Bools() []bool {
+	r := make([]bool, a.numel())
+	if len(r) > 0 {
+		a.copyData(Bool, unsafe.Pointer(&r[0]), len(r))
+	}
+	return r
+}
+
+// Uint8s returns the elements as a []uint8
+func (a Tensor) Uint8s() []uint8 {
+	r := make([]uint8, a.numel())
+	if len(r) > 0 {
+		a.copyData(Byte, unsafe.Pointer(&r[0]), len(r))
+	}
+	return r
+}
+
+// Int8s returns the elements as a []int8
+func (a Tensor) Int8s() []int8 {
+	r := make([]int8, a.numel())
+	if len(r) > 0 {
+		a.copyData(Char, unsafe.Pointer(&r[0]), len(r))
+	}
+	return r
+}
+
+// Int16s returns the elements as a []int16
+func (a Tensor) Int16s() []int16 {
+	r := make([]int16, a.numel())
+	if len(r) > 0 {
+		a.copyData(Short, unsafe.Pointer(&r[0]), len(r)*2)
+	}
+	return r
+}
+
+// Int32s returns the elements as a []int32
+func (a Tensor) Int32s() []int32 {
+	r := make([]int32, a.numel())
+	if len(r) > 0 {
+		a.copyData(Int, unsafe.Pointer(&r[0]), len(r)*4)
+	}
+	return r
+}
+
+// Int64s returns the elements as a []int64
+func (a Tensor) Int64s() []int64 {
+	r := make([]int64, a.numel())
+	if len(r) > 0 {
+		a.copyData(Long, unsafe.Pointer(&r[0]), len(r)*8)
+	}
+	return r
+}
+
+// Float32s returns the elements as a []float32
+func (a Tensor) Float32s() []float32 {
+	r := make([]float32, a.numel())
+	if len(r) > 0 {
+		a.copyData(Float, unsafe.Pointer(&r[0]), len(r)*4)
+	}
+	return r
+}
+
+// Float64s returns the elements as a []float64
+func (a Tensor) Float64s() []float64 {
+	r := make([]float64, a.numel())
+	if len(r) > 0 {
+		a.copyData(Double, unsafe.Pointer(&r[0]), len(r)*8)
+	}
+	return r
+}
+
+// Complex64s returns the elements as a []complex64.  NewTensor creates
+// ComplexFloat tensors from []complex64.
+func (a Tensor) Complex64s() []complex64 {
+	r := make([]complex64, a.numel())
+	if len(r) > 0 {
+		a.copyData(ComplexFloat, unsafe.Pointer(&r[0]), len(r)*8)
+	}
+	return r
+}
+
+// Complex128s returns the elements as a []complex128.  NewTensor creates
+// ComplexDouble tensors from []complex128.
+func (a Tensor) Complex128s() []complex128 {
+	r := make([]complex128, a.numel())
+	if len(r) > 0 {
+		a.copyData(ComplexDouble, unsafe.Pointer(&r[0]), len(r)*16)
+	}
+	return r
+}
+
+// halfBits returns the bits of Half elements as a []uint16, which is how
+// NewTensor accepts Half elements.
+func (a Tensor) halfBits() []uint16 {
+	r := make([]uint16, a.numel())
+	if len(r) > 0 {
+		a.copyData(Half, unsafe.Pointer(&r[0]), len(r)*2)
+	}
+	return r
+}
+
+// Float16s returns the elements as a []float16.Float16
+func (a Tensor) Float16s() []float16.Float16 {
+	r := make([]float16.Float16, a.numel())
+	if len(r) > 0 {
+		a.copyData(Half, unsafe.Pointer(&r[0]), len(r)*2)
+	}
+	return r
+}
+
+// BFloat16s returns the bits of the elements as BFloat16 in a []uint16, which
+// NewTensor accepts with the option "dtype" BFloat16.
+func (a Tensor) BFloat16s() []uint16 {
+	r := make([]uint16, a.numel())
+	if len(r) > 0 {
+		a.copyData(BFloat16, unsafe.Pointer(&r[0]), len(r)*2)
+	}
+	return r
+}
+
+// ToSlice returns the elements in a nested Go slice whose shape and element
+// type mirror the arguments that NewTensor accepts.  For example, ToSlice
+// returns a [][]float32 for a 2-dimensional tensor of dtype Float, and a Go
+// value like Item does for a 0-dimensional tensor.
+func (a Tensor) ToSlice() interface{} {
+	var flat interface{}
+	switch a.Dtype() {
+	case Bool:
+		flat = a.Bools()
+	case Byte:
+		flat = a.Uint8s()
+	case Char:
+		flat = a.Int8s()
+	case Short:
+		flat = a.Int16s()
+	case Int:
+		flat = a.Int32s()
+	case Long:
+		flat = a.Int64s()
+	case Half:
+		flat = a.halfBits()
+	case BFloat16:
+		flat = a.BFloat16s()
+	case Float:
+		flat = a.Float32s()
+	case Double:
+		flat = a.Float64s()
+	case ComplexFloat:
+		flat = a.Complex64s()
+	case ComplexDouble:
+		flat = a.Complex128s()
+	default:
+		log.Panicf("ToSlice: Dtype %v not supported now.", a.Dtype())
+	}
+
+	shape := a.Shape()
+	if len(shape) == 0 {
+		return reflect.ValueOf(flat).Index(0).Interface()
+	}
+	return reshapeSlice(reflect.ValueOf(flat), shape).Interface()
+}
+
+// reshapeSlice cuts the flat slice into nested slices of the given shape.
+func reshapeSlice(flat reflect.Value, shape []int64) reflect.Value {
+	if len(shape) == 1 {
+		return flat
+	}
+	typ := flat.Type()
+	for range shape[1:] {
+		typ = reflect.SliceOf(typ)
+	}
+	n := int(shape[0])
+	r := reflect.MakeSlice(typ, n, n)
+	if n == 0 {
+		return r
+	}
+	stride := flat.Len() / n
+	for i := 0; i < n; i++ {
+		r.Index(i).Set(reshapeSlice(flat.Slice(i*stride, (i+1)*stride), shape[1:]))
+	}
+	return r
+}
+
+func (a Tensor) numel() int {
+	n := int64(1)
+	for _, d := range a.Shape() {
+		n *= d
+	}
+	return int(n)
+}
+
+func (a Tensor) copyData(dtype Dtype, data unsafe.Pointer, nbytes int) {
+	MustNil(unsafe.Pointer(C.Tensor_CopyData(C.Tensor(*a.T), C.int8_t(dtype),
+		data, C.int64_t(nbytes))))
+}
@@ -0,0 +1,52 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestTensorFlatSlices(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2, 3}, {4, 5, 6}})
+	a.Equal([]float32{1, 2, 3, 4, 5, 6}, x.Float32s())
+	a.Equal([]float64{1, 2, 3, 4, 5, 6}, x.Float64s())
+	a.Equal([]int64{1, 2, 3, 4, 5, 6}, x.Int64s())
+	a.Equal([]int32{1, 2, 3, 4, 5, 6}, x.Int32s())
+
+	b := torch.NewTensor([]bool{true, false, true})
+	a.Equal([]bool{true, false, true}, b.Bools())
+	a.Equal([]uint8{1, 0, 1}, b.Uint8s())
+
+	// Non-contiguous tensors are copied in the row-major order of the view.
+	y := x.Transpose(0, 1)
+	a.Equal([]float32{1, 4, 2, 5, 3, 6}, y.Float32s())
+
+	a.Equal([]float32{}, torch.Empty([]int64{0, 3}, false).Float32s())
+}
+
+func TestTensorToSlice(t *testing.T) {
+	a := assert.New(t)
+	{
+		data := [][]float32{{1, 2, 3}, {4, 5, 6}}
+		a.Equal(data, torch.NewTensor(data).ToSlice())
+	}
+	{
+		data := [][][]int64{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}}
+		a.Equal(data, torch.NewTensor(data).ToSlice())
+	}
+	{
+		data := []bool{true, false}
+		a.Equal(data, torch.NewTensor(data).ToSlice())
+	}
+	{
+		x := torch.NewTensor([][]float64{{1, 2}, {3, 4}})
+		a.Equal([][]float64{{1, 3}, {2, 4}}, x.Transpose(0, 1).ToSlice())
+		a.Equal(float64(4), x.Index(1, 1).ToSlice())
+	}
+	{
+		x := torch.Empty([]int64{0, 2}, false)
+		a.Equal([][]float32{}, x.ToSlice())
+	}
+}
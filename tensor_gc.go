@@ -8,6 +8,7 @@ import "C"
 import (
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -52,6 +53,18 @@ var (
 // ordinary users don't have to care about. GoTorch provides high-level APIs
 // that wraps the two functions.
 
+// For deterministic lifetime control without whole-heap GCs, `Tensor.Close()`
+// frees a tensor immediately, and `torch.Scope()` closes the tensors created
+// while a function runs, unless they are kept by `TensorScope.Keep()`.  Go
+// has no goroutine local storage, so a scope records the tensors created by
+// all goroutines during its call, rather than those of the calling goroutine.
+// A tensor recorded by several scopes, e.g., nested scopes or scopes running
+// in concurrent goroutines, is closed when the last of them returns, so no
+// scope frees a tensor still used by another.  Each Go `Tensor` refers to its
+// own C++ `at::Tensor` handle, which shares the storage with other handles by
+// reference counting, so closing a tensor never frees the storage used by
+// other tensors, e.g., the parameters updated by an in-place operation.
+
 // SetTensorFinalizer sets a finalizer to the tensor
 func SetTensorFinalizer(t *unsafe.Pointer) {
 	// We don't want the following conditional and the finalizer using
@@ -61,11 +74,134 @@ func SetTensorFinalizer(t *unsafe.Pointer) {
 		tensorFinalizersWG.Add(1)
 	}
 	runtime.SetFinalizer(t, func(ct *unsafe.Pointer) {
-		C.Tensor_Close(C.Tensor(*ct))
+		closeTensor(ct)
 		if p != 0 {
 			tensorFinalizersWG.Done()
 		}
 	})
+	if *t != nil {
+		recordTensor(t)
+		if atomic.LoadInt32(&activeScopes) > 0 {
+			scopeTensor(t)
+		}
+	}
+}
+
+// closeTensor frees the C++ tensor *t unless it has been freed.
+func closeTensor(t *unsafe.Pointer) {
+	if p := atomic.SwapPointer(t, nil); p != nil {
+		forgetTensor(p)
+		C.Tensor_Close(C.Tensor(p))
+	}
+}
+
+// Close frees the tensor without waiting for the Go garbage collector.  It is
+// safe to close a tensor more than once, or to close copies of a Tensor value,
+// which refer to the same C++ tensor.  A closed tensor must not be used
+// except for Defined, which returns false.
+func (a Tensor) Close() {
+	if a.T != nil {
+		closeTensor(a.T)
+	}
+}
+
+// TensorScope records the tensors created during a call to Scope.
+type TensorScope struct {
+	tensors []*unsafe.Pointer
+}
+
+// scopedTensor is the state of a tensor recorded by active scopes.
+type scopedTensor struct {
+	scopes int
+	kept   bool
+}
+
+var (
+	scopesMu     sync.Mutex
+	active       = make(map[*TensorScope]bool)
+	activeScopes int32
+	scoped       = make(map[*unsafe.Pointer]*scopedTensor)
+)
+
+// Scope calls f and closes the tensors created by any goroutine while f runs,
+// except those passed to Keep, when f returns or panics.  For example, a
+// training loop could free the intermediate results of each iteration by
+//
+//	for _, batch := range batches {
+//		torch.Scope(func(s *torch.TensorScope) {
+//			loss := F.NLLLoss(model.Forward(batch.Data), batch.Target, ...)
+//			loss.Backward()
+//			opt.Step()
+//		})
+//	}
+//
+// Tensors created while other scopes run, e.g., in nested scopes or in scopes
+// of other goroutines, are closed when the last of these scopes returns.  So
+// goroutines creating tensors that outlive the current iteration, like data
+// loaders, should Keep them.
+func Scope(f func(s *TensorScope)) {
+	s := &TensorScope{}
+	scopesMu.Lock()
+	active[s] = true
+	atomic.AddInt32(&activeScopes, 1)
+	scopesMu.Unlock()
+	defer s.close()
+	f(s)
+}
+
+// Keep makes t survive the end of the scopes and returns t.  A kept tensor is
+// freed by the Go garbage collector or Close, like tensors created outside
+// scopes.
+func (s *TensorScope) Keep(t Tensor) Tensor {
+	return Keep(t)
+}
+
+// Keep makes t survive the end of all scopes running when t was created and
+// returns t.  Libraries caching tensors created in the calls of their users,
+// who may run these calls in scopes, keep the cached tensors.
+func Keep(t Tensor) Tensor {
+	if t.T != nil {
+		scopesMu.Lock()
+		if st, ok := scoped[t.T]; ok {
+			st.kept = true
+		}
+		scopesMu.Unlock()
+	}
+	return t
+}
+
+// scopeTensor records the new tensor t in the active scopes.
+func scopeTensor(t *unsafe.Pointer) {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+	if len(active) == 0 {
+		return
+	}
+	scoped[t] = &scopedTensor{scopes: len(active)}
+	for s := range active {
+		s.tensors = append(s.tensors, t)
+	}
+}
+
+func (s *TensorScope) close() {
+	var closing []*unsafe.Pointer
+	scopesMu.Lock()
+	delete(active, s)
+	atomic.AddInt32(&activeScopes, -1)
+	for _, t := range s.tensors {
+		st := scoped[t]
+		if st.scopes--; st.scopes == 0 {
+			delete(scoped, t)
+			if !st.kept {
+				closing = append(closing, t)
+			}
+		}
+	}
+	s.tensors = nil
+	scopesMu.Unlock()
+	for _, t := range closing {
+		closeTensor(t)
+	}
 }
 
 // FinishGC should be called right after a train/predict loop
@@ -0,0 +1,109 @@
+package gotorch_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestTensorClose(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 2})
+	y := x
+	x.Close()
+	a.False(x.Defined())
+	a.False(y.Defined())
+	a.NotPanics(func() { x.Close(); y.Close() })
+	a.NotPanics(func() { torch.Tensor{}.Close() })
+}
+
+func TestScope(t *testing.T) {
+	a := assert.New(t)
+	w := torch.NewTensor([]float32{1, 2})
+	var tmp, kept, inner, innerKept torch.Tensor
+	torch.Scope(func(s *torch.TensorScope) {
+		tmp = w.Add(w, 1)
+		kept = s.Keep(torch.Mul(w, w))
+		torch.Scope(func(s *torch.TensorScope) {
+			inner = torch.Neg(w)
+			innerKept = s.Keep(torch.Neg(w))
+		})
+		// The enclosing scope is still running.
+		a.True(inner.Defined())
+		a.True(tmp.Defined())
+		// In-place operations return new handles to the storage of w.
+		w.AddI(torch.NewTensor([]float32{1, 1}), 1)
+	})
+	a.False(tmp.Defined())
+	a.False(inner.Defined())
+	a.True(kept.Defined())
+	a.True(innerKept.Defined())
+	a.Equal([]float32{1, 4}, kept.Float32s())
+	a.Equal([]float32{2, 3}, w.Float32s())
+
+	outside := torch.Neg(w)
+	torch.Scope(func(s *torch.TensorScope) {})
+	a.True(outside.Defined())
+}
+
+func TestScopeInGoroutines(t *testing.T) {
+	a := assert.New(t)
+	results := make([]torch.Tensor, 4)
+	tmps := make([]torch.Tensor, 4)
+	torch.Scope(func(s *torch.TensorScope) {
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				tmps[i] = torch.NewTensor([]int64{int64(i)})
+				results[i] = torch.Keep(tmps[i].Add(tmps[i], 1))
+			}(i)
+		}
+		wg.Wait()
+	})
+	for i, r := range results {
+		a.Equal([]int64{2 * int64(i)}, r.Int64s())
+		a.False(tmps[i].Defined())
+	}
+}
+
+func TestConcurrentScopes(t *testing.T) {
+	a := assert.New(t)
+	var x, y torch.Tensor
+	running, created, done := make(chan bool), make(chan bool), make(chan bool)
+	go func() {
+		torch.Scope(func(s *torch.TensorScope) {
+			running <- true
+			<-created
+			y = torch.NewTensor([]float32{2})
+			<-done
+		})
+		done <- true
+	}()
+	<-running
+	torch.Scope(func(s *torch.TensorScope) {
+		x = torch.NewTensor([]float32{1})
+	})
+	// The other scope, which recorded x too, is still running.
+	a.True(x.Defined())
+	created <- true
+	done <- true
+	<-done
+	a.False(x.Defined())
+	a.False(y.Defined())
+}
+
+func TestScopePanic(t *testing.T) {
+	a := assert.New(t)
+	var x torch.Tensor
+	a.Panics(func() {
+		torch.Scope(func(s *torch.TensorScope) {
+			x = torch.NewTensor([]float32{1})
+			panic("boom")
+		})
+	})
+	a.False(x.Defined())
+}
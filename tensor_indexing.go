@@ -0,0 +1,210 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// The kinds of TensorIndex, which must be consistent with
+// cgotorch/indexing.h.
+const (
+	indexInteger int8 = iota
+	indexSlice
+	indexNone
+	indexEllipsis
+	indexBoolean
+	indexTensor
+)
+
+// TensorIndex mimics at::indexing::TensorIndex.  It is an element of the
+// indices passed to Tensor.At and Tensor.IndexPut.
+type TensorIndex struct {
+	kind   int8
+	ints   [3]int64
+	tensor Tensor
+}
+
+// IndexSymbol is the type of the indices that Python spells with symbols.
+type IndexSymbol int8
+
+const (
+	// All selects all elements of a dimension, like `:` in Python.
+	All IndexSymbol = iota
+	// None inserts a new dimension of size 1, like `None` in Python.
+	None
+	// Ellipsis expands to as many All as needed, like `...` in Python.
+	Ellipsis
+)
+
+// Slice returns the index start:stop:step in Python.  The step defaults to 1.
+func Slice(start, stop int64, step ...int64) TensorIndex {
+	s := int64(1)
+	if len(step) > 0 {
+		s = step[0]
+	}
+	return TensorIndex{kind: indexSlice, ints: [3]int64{start, stop, s}}
+}
+
+// SliceFrom returns the index start: in Python.
+func SliceFrom(start int64) TensorIndex {
+	return Slice(start, math.MaxInt64)
+}
+
+// SliceTo returns the index :stop in Python.
+func SliceTo(stop int64) TensorIndex {
+	return Slice(0, stop)
+}
+
+// toTensorIndex converts an integer, a bool, a Tensor, an IndexSymbol, or a
+// TensorIndex into a TensorIndex.
+func toTensorIndex(i interface{}) (TensorIndex, error) {
+	switch v := i.(type) {
+	case TensorIndex:
+		return v, nil
+	case IndexSymbol:
+		switch v {
+		case All:
+			return Slice(0, math.MaxInt64), nil
+		case None:
+			return TensorIndex{kind: indexNone}, nil
+		case Ellipsis:
+			return TensorIndex{kind: indexEllipsis}, nil
+		}
+		return TensorIndex{}, fmt.Errorf("unknown index symbol %d", v)
+	case Tensor:
+		return TensorIndex{kind: indexTensor, tensor: v}, nil
+	case bool:
+		b := int64(0)
+		if v {
+			b = 1
+		}
+		return TensorIndex{kind: indexBoolean, ints: [3]int64{b}}, nil
+	}
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return TensorIndex{kind: indexInteger, ints: [3]int64{v.Int()}}, nil
+	}
+	return TensorIndex{}, fmt.Errorf("cannot use %v of type %T as a tensor index", i, i)
+}
+
+// cTensorIndices flattens indices into the arrays expected by
+// C.Tensor_IndexWith and C.Tensor_IndexPut_, or returns the *TorchError of op
+// on inputs if an index has an unsupported type.  The arrays have an extra
+// element so that taking the address of the first element is valid even if
+// indices is empty.
+func cTensorIndices(op string, indices []interface{}, inputs ...Tensor) (
+	[]C.int8_t, []C.int64_t, []C.Tensor, error) {
+	kinds := make([]C.int8_t, len(indices)+1)
+	ints := make([]C.int64_t, 3*len(indices)+1)
+	tensors := make([]C.Tensor, len(indices)+1)
+	for i, idx := range indices {
+		ti, err := toTensorIndex(idx)
+		if err != nil {
+			return nil, nil, nil, newTorchError(op, err.Error(), inputs...)
+		}
+		kinds[i] = C.int8_t(ti.kind)
+		for j, n := range ti.ints {
+			ints[3*i+j] = C.int64_t(n)
+		}
+		if ti.kind == indexTensor {
+			tensors[i] = C.Tensor(*ti.tensor.T)
+		}
+	}
+	return kinds, ints, tensors, nil
+}
+
+// At mimics Python's indexing a[indices], where each index is an integer, a
+// bool, a Tensor of indices or a boolean mask, a TensorIndex like Slice, or
+// one of All, None, and Ellipsis.  For example, the Python expression
+// a[1:3, :, 2] is
+//
+//	a.At(torch.Slice(1, 3), torch.All, 2)
+func (a Tensor) At(indices ...interface{}) Tensor {
+	return Must(a.TryAt(indices...))
+}
+
+// TryAt is At that returns an error rather than panics
+func (a Tensor) TryAt(indices ...interface{}) (Tensor, error) {
+	kinds, ints, tensors, err := cTensorIndices("At", indices, a)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("At", C.Tensor_IndexWith(C.Tensor(*a.T),
+		&kinds[0], &ints[0], &tensors[0], C.int64_t(len(indices)), &t), &t, a)
+}
+
+// IndexPut mimics Python's index assignment a[indices] = value, which calls
+// torch.Tensor.index_put_.  The indices are as those of At.
+func (a Tensor) IndexPut(value Tensor, indices ...interface{}) {
+	if err := a.TryIndexPut(value, indices...); err != nil {
+		panic(err)
+	}
+}
+
+// TryIndexPut is IndexPut that returns an error rather than panics
+func (a Tensor) TryIndexPut(value Tensor, indices ...interface{}) error {
+	kinds, ints, tensors, err := cTensorIndices("IndexPut", indices, a, value)
+	if err != nil {
+		return err
+	}
+	return CheckNil("IndexPut", unsafe.Pointer(C.Tensor_IndexPut_(C.Tensor(*a.T),
+		&kinds[0], &ints[0], &tensors[0], C.int64_t(len(indices)),
+		C.Tensor(*value.T))), a, value)
+}
+
+// Narrow torch.narrow
+func Narrow(a Tensor, dim, start, length int64) Tensor {
+	return Must(TryNarrow(a, dim, start, length))
+}
+
+// TryNarrow is Narrow that returns an error rather than panics
+func TryNarrow(a Tensor, dim, start, length int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Narrow", C.Narrow(C.Tensor(*a.T), C.int64_t(dim),
+		C.int64_t(start), C.int64_t(length), &t), &t, a)
+}
+
+// Narrow torch.narrow
+func (a Tensor) Narrow(dim, start, length int64) Tensor {
+	return Narrow(a, dim, start, length)
+}
+
+// Select torch.select
+func Select(a Tensor, dim, index int64) Tensor {
+	return Must(TrySelect(a, dim, index))
+}
+
+// TrySelect is Select that returns an error rather than panics
+func TrySelect(a Tensor, dim, index int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Select", C.Select(C.Tensor(*a.T), C.int64_t(dim),
+		C.int64_t(index), &t), &t, a)
+}
+
+// Select torch.select
+func (a Tensor) Select(dim, index int64) Tensor {
+	return Select(a, dim, index)
+}
+
+// Slice returns a view of the elements start:end:step along the dimension
+// dim, like torch.Tensor.slice in C++.
+func (a Tensor) Slice(dim, start, end, step int64) Tensor {
+	return Must(a.TrySlice(dim, start, end, step))
+}
+
+// TrySlice is Slice that returns an error rather than panics
+func (a Tensor) TrySlice(dim, start, end, step int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Slice", C.Slice(C.Tensor(*a.T), C.int64_t(dim),
+		C.int64_t(start), C.int64_t(end), C.int64_t(step), &t), &t, a)
+}
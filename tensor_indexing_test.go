@@ -0,0 +1,86 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+// >>> a = torch.arange(24.).view(2, 3, 4)
+func TestTensorAt(t *testing.T) {
+	a := assert.New(t)
+	x := torch.Arange(0, 24, 1, false).View(2, 3, 4)
+
+	// >>> a[1, 2, 3]
+	a.Equal(float32(23), x.At(1, 2, 3).Item())
+	// >>> a[:, 1:3, 2]
+	a.Equal([][]float32{{6, 10}, {18, 22}},
+		x.At(torch.All, torch.Slice(1, 3), 2).ToSlice())
+	// >>> a[0, :, ::2]
+	a.Equal([][]float32{{0, 2}, {4, 6}, {8, 10}},
+		x.At(0, torch.All, torch.Slice(0, 4, 2)).ToSlice())
+	// >>> a[..., -1]
+	a.Equal([][]float32{{3, 7, 11}, {15, 19, 23}},
+		x.At(torch.Ellipsis, -1).ToSlice())
+	// >>> a[None, 1].shape
+	a.Equal([]int64{1, 3, 4}, x.At(torch.None, 1).Shape())
+	// >>> a[1, 1:]
+	a.Equal([]int64{2, 4}, x.At(1, torch.SliceFrom(1)).Shape())
+	// >>> a[1, :1]
+	a.Equal([]int64{1, 4}, x.At(1, torch.SliceTo(1)).Shape())
+
+	a.Panics(func() { x.At(3) })
+	a.Panics(func() { x.At("a") })
+	_, err := x.TryAt(torch.All, "a")
+	a.Equal("At", err.(*torch.TorchError).Op)
+	_, err = x.TryAt(torch.IndexSymbol(7))
+	a.Error(err)
+}
+
+func TestTensorAtAdvanced(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, -2}, {-3, 4}})
+
+	// >>> x[x > 0]
+	mask := torch.NewTensor([][]bool{{true, false}, {false, true}})
+	a.Equal([]float32{1, 4}, x.At(mask).ToSlice())
+
+	// >>> x[torch.tensor([1, 0])]
+	idx := torch.NewTensor([]int64{1, 0})
+	a.Equal([][]float32{{-3, 4}, {1, -2}}, x.At(idx).ToSlice())
+}
+
+func TestTensorIndexPut(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, -2}, {-3, 4}})
+
+	// >>> x[x < 0] = 0
+	mask := torch.NewTensor([][]bool{{false, true}, {true, false}})
+	x.IndexPut(torch.NewTensor([]float32{0}), mask)
+	a.Equal([][]float32{{1, 0}, {0, 4}}, x.ToSlice())
+
+	// >>> x[:, 1] = torch.tensor([7., 8.])
+	x.IndexPut(torch.NewTensor([]float32{7, 8}), torch.All, 1)
+	a.Equal([][]float32{{1, 7}, {0, 8}}, x.ToSlice())
+
+	err := x.TryIndexPut(torch.NewTensor([]float32{1, 2, 3}), torch.All, 1)
+	a.Equal("IndexPut", err.(*torch.TorchError).Op)
+	a.Error(x.TryIndexPut(torch.NewTensor([]float32{0}), 1.5))
+}
+
+func TestNarrowSelectSlice(t *testing.T) {
+	a := assert.New(t)
+	x := torch.Arange(0, 12, 1, false).View(3, 4)
+
+	a.Equal([][]float32{{4, 5, 6, 7}, {8, 9, 10, 11}}, x.Narrow(0, 1, 2).ToSlice())
+	a.Equal([]float32{1, 5, 9}, torch.Select(x, 1, 1).ToSlice())
+	a.Equal([][]float32{{1, 3}, {5, 7}, {9, 11}}, x.Slice(1, 1, 4, 2).ToSlice())
+
+	_, err := torch.TryNarrow(x, 0, 2, 2)
+	a.Equal("Narrow", err.(*torch.TorchError).Op)
+	_, err = torch.TrySelect(x, 2, 0)
+	a.Equal("Select", err.(*torch.TorchError).Op)
+	_, err = x.TrySlice(1, 0, 4, 0)
+	a.Equal("Slice", err.(*torch.TorchError).Op)
+}
@@ -0,0 +1,146 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+// The following methods modify a in-place and return a, like ExpI and AddI.
+// Autograd can't compute the gradients with respect to a leaf tensor
+// overwritten in the forward pass, so these methods panic, and their Try
+// versions return an error, if a is a leaf tensor requiring grad and
+// gradient computation is enabled.  To initialize or update parameters, call
+// them in NoGrad:
+//
+//	torch.NoGrad(func() { w.NormalI(0, 0.02) })
+
+// FillI fills a with value
+func (a *Tensor) FillI(value float64) Tensor {
+	return Must(a.TryFillI(value))
+}
+
+// TryFillI is FillI that returns an error rather than panics
+func (a *Tensor) TryFillI(value float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("FillI",
+		C.Tensor_Fill_(C.Tensor(*a.T), C.double(value), &t), &t, *a)
+}
+
+// ZeroI fills a with zeros
+func (a *Tensor) ZeroI() Tensor {
+	return Must(a.TryZeroI())
+}
+
+// TryZeroI is ZeroI that returns an error rather than panics
+func (a *Tensor) TryZeroI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ZeroI", C.Tensor_Zero_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// CopyI copies the elements of src into a.  src could be on a different
+// device and have a different dtype, and it broadcasts to the shape of a.
+func (a *Tensor) CopyI(src Tensor) Tensor {
+	return Must(a.TryCopyI(src))
+}
+
+// TryCopyI is CopyI that returns an error rather than panics
+func (a *Tensor) TryCopyI(src Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("CopyI",
+		C.Tensor_Copy_(C.Tensor(*a.T), C.Tensor(*src.T), 0, &t), &t, *a, src)
+}
+
+// ResizeI changes the shape of a.  It keeps the elements in the storage and
+// leaves new elements uninitialized.
+func (a *Tensor) ResizeI(shape ...int64) Tensor {
+	return Must(a.TryResizeI(shape...))
+}
+
+// TryResizeI is ResizeI that returns an error rather than panics
+func (a *Tensor) TryResizeI(shape ...int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ResizeI", C.Tensor_Resize_(C.Tensor(*a.T),
+		dimsPtr(shape), C.int64_t(len(shape)), &t), &t, *a)
+}
+
+// UniformI fills a with samples from the uniform distribution in [low, high).
+// It accepts the option "generator".
+func (a *Tensor) UniformI(low, high float64, opt ...map[string]interface{}) Tensor {
+	return Must(a.TryUniformI(low, high, opt...))
+}
+
+// TryUniformI is UniformI that returns an error rather than panics
+func (a *Tensor) TryUniformI(low, high float64,
+	opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("UniformI", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("UniformI", C.Tensor_Uniform_(C.Tensor(*a.T),
+		C.double(low), C.double(high), g, &t), &t, *a)
+}
+
+// NormalI fills a with samples from the normal distribution.  It accepts the
+// option "generator".
+func (a *Tensor) NormalI(mean, std float64, opt ...map[string]interface{}) Tensor {
+	return Must(a.TryNormalI(mean, std, opt...))
+}
+
+// TryNormalI is NormalI that returns an error rather than panics
+func (a *Tensor) TryNormalI(mean, std float64,
+	opt ...map[string]interface{}) (Tensor, error) {
+	g, err := generatorOption("NormalI", opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	return newTensorOrError("NormalI", C.Tensor_Normal_(C.Tensor(*a.T),
+		C.double(mean), C.double(std), g, &t), &t, *a)
+}
+
+// ReluI computes relu in-place
+func (a *Tensor) ReluI() Tensor {
+	return Must(a.TryReluI())
+}
+
+// TryReluI is ReluI that returns an error rather than panics
+func (a *Tensor) TryReluI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ReluI", C.Relu_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// LeakyReluI computes leaky relu in-place
+func (a *Tensor) LeakyReluI(negativeSlope float64) Tensor {
+	return Must(a.TryLeakyReluI(negativeSlope))
+}
+
+// TryLeakyReluI is LeakyReluI that returns an error rather than panics
+func (a *Tensor) TryLeakyReluI(negativeSlope float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LeakyReluI",
+		C.LeakyRelu_(C.Tensor(*a.T), C.double(negativeSlope), &t), &t, *a)
+}
+
+// TanhI computes tanh in-place
+func (a *Tensor) TanhI() Tensor {
+	return Must(a.TryTanhI())
+}
+
+// TryTanhI is TanhI that returns an error rather than panics
+func (a *Tensor) TryTanhI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("TanhI", C.Tanh_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// SigmoidI computes sigmoid in-place
+func (a *Tensor) SigmoidI() Tensor {
+	return Must(a.TrySigmoidI())
+}
+
+// TrySigmoidI is SigmoidI that returns an error rather than panics
+func (a *Tensor) TrySigmoidI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("SigmoidI", C.Sigmoid_(C.Tensor(*a.T), &t), &t, *a)
+}
@@ -0,0 +1,121 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestFillZeroCopyI(t *testing.T) {
+	a := assert.New(t)
+	x := torch.Empty([]int64{2, 2}, false)
+	x.FillI(3)
+	a.Equal([]float32{3, 3, 3, 3}, x.Float32s())
+	x.ZeroI()
+	a.Equal([]float32{0, 0, 0, 0}, x.Float32s())
+
+	// CopyI broadcasts and casts src
+	x.CopyI(torch.NewTensor([]float64{1, 2}))
+	a.Equal([]float32{1, 2, 1, 2}, x.Float32s())
+	_, e := x.TryCopyI(torch.NewTensor([]float64{1, 2, 3}))
+	a.Error(e)
+}
+
+func TestResizeI(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 2, 3, 4})
+	x.ResizeI(2, 2)
+	a.Equal([]int64{2, 2}, x.Shape())
+	a.Equal([]float32{1, 2, 3, 4}, x.Float32s())
+	x.ResizeI(3)
+	a.Equal([]float32{1, 2, 3}, x.Float32s())
+}
+
+func TestRandomI(t *testing.T) {
+	a := assert.New(t)
+	x := torch.Empty([]int64{1000}, false)
+	x.UniformI(-1, 1)
+	for _, v := range x.Float32s() {
+		a.True(v >= -1 && v < 1)
+	}
+
+	// libtorch samples tensors of different sizes by different algorithms, so
+	// compare tensors of the same size.
+	for _, n := range []int64{3, 1000} {
+		x := torch.Empty([]int64{n}, false)
+		y := torch.Empty([]int64{n}, false)
+		x.NormalI(0, 1, map[string]interface{}{"generator": torch.NewGenerator(1)})
+		y.NormalI(0, 1, map[string]interface{}{"generator": torch.NewGenerator(1)})
+		a.Equal(x.Float32s(), y.Float32s())
+	}
+}
+
+func TestActivationsI(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{-2, 0, 2})
+	x.LeakyReluI(0.5)
+	a.Equal([]float32{-1, 0, 2}, x.Float32s())
+	x.ReluI()
+	a.Equal([]float32{0, 0, 2}, x.Float32s())
+
+	y := torch.NewTensor([]float32{-1, 0, 1})
+	z := torch.Sigmoid(y)
+	y.SigmoidI()
+	a.True(torch.AllClose(z, y))
+	w := torch.Tanh(y)
+	y.TanhI()
+	a.True(torch.AllClose(w, y))
+}
+
+func TestInplaceOnLeafRequiringGrad(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{-1, 0, 1})
+	x.SetRequiresGrad(true)
+
+	_, e := x.TryFillI(1)
+	a.Error(e)
+	_, e = x.TryResizeI(2)
+	a.Error(e)
+	_, e = x.TryReluI()
+	a.Error(e)
+	a.Panics(func() { x.NormalI(0, 1) })
+	_, e = x.TryClampI(0, 1)
+	a.Error(e)
+	a.Contains(e.Error(), "NoGrad")
+	_, e = x.TryExpI()
+	a.Error(e)
+	_, e = x.TryMulScalarI(2)
+	a.Error(e)
+	one := torch.NewTensor([]float32{1, 1, 1})
+	for _, f := range []func() (torch.Tensor, error){
+		x.TryLogI, x.TryLog1pI, x.TrySqrtI, x.TryRsqrtI, x.TryAbsI,
+		x.TryNegI, x.TrySignI, x.TryFloorI, x.TryCeilI, x.TryRoundI,
+		x.TrySinI, x.TryCosI, x.TryReciprocalI,
+		func() (torch.Tensor, error) { return x.TryAddScalarI(1) },
+		func() (torch.Tensor, error) { return x.TrySubScalarI(1) },
+		func() (torch.Tensor, error) { return x.TryDivScalarI(2) },
+		func() (torch.Tensor, error) { return x.TryPowI(one) },
+		func() (torch.Tensor, error) { return x.TryPowScalarI(2) },
+		func() (torch.Tensor, error) { return x.TryAddI(one, 1) },
+		func() (torch.Tensor, error) { return x.TrySubI(one, 1) },
+		func() (torch.Tensor, error) { return x.TryMulI(one) },
+		func() (torch.Tensor, error) { return x.TryDivI(one) },
+	} {
+		_, e = f()
+		a.Contains(e.Error(), "NoGrad")
+	}
+	a.Contains(x.TryIndexPut(one.Narrow(0, 0, 1), 0).Error(), "NoGrad")
+	a.Equal([]float32{-1, 0, 1}, x.Float32s())
+
+	torch.NoGrad(func() {
+		x.FillI(2)
+		x.UniformI(0, 1)
+	})
+	a.True(x.RequiresGrad())
+
+	// Non-leaf tensors could be modified in-place
+	y := x.MulScalar(2)
+	_, e = y.TryReluI()
+	a.NoError(e)
+}
@@ -0,0 +1,652 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+// Element-wise math operations.  Binary operations broadcast their operands
+// like PyTorch does.  Methods with the suffix I work in-place, like AddI.
+// Each operation Xxx panics if libtorch reports an error, e.g., an
+// unsupported dtype, and its counterpart TryXxx returns the error.
+
+// Exp torch.exp
+func Exp(a Tensor) Tensor {
+	return a.Exp()
+}
+
+// Exp torch.exp
+func (a Tensor) Exp() Tensor {
+	return Must(TryExp(a))
+}
+
+// TryExp is Exp that returns an error rather than panics
+func TryExp(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Exp",
+		C.Exp(C.Tensor(*a.T), &t), &t, a)
+}
+
+// ExpI computes torch.exp in-place
+func (a *Tensor) ExpI() Tensor {
+	return Must(a.TryExpI())
+}
+
+// TryExpI is ExpI that returns an error rather than panics
+func (a *Tensor) TryExpI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ExpI", C.Exp_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Log torch.log
+func Log(a Tensor) Tensor {
+	return a.Log()
+}
+
+// Log torch.log
+func (a Tensor) Log() Tensor {
+	return Must(TryLog(a))
+}
+
+// TryLog is Log that returns an error rather than panics
+func TryLog(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Log",
+		C.Log(C.Tensor(*a.T), &t), &t, a)
+}
+
+// LogI computes torch.log in-place
+func (a *Tensor) LogI() Tensor {
+	return Must(a.TryLogI())
+}
+
+// TryLogI is LogI that returns an error rather than panics
+func (a *Tensor) TryLogI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("LogI", C.Log_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Log1p torch.log1p
+func Log1p(a Tensor) Tensor {
+	return a.Log1p()
+}
+
+// Log1p torch.log1p
+func (a Tensor) Log1p() Tensor {
+	return Must(TryLog1p(a))
+}
+
+// TryLog1p is Log1p that returns an error rather than panics
+func TryLog1p(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Log1p",
+		C.Log1p(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Log1pI computes torch.log1p in-place
+func (a *Tensor) Log1pI() Tensor {
+	return Must(a.TryLog1pI())
+}
+
+// TryLog1pI is Log1pI that returns an error rather than panics
+func (a *Tensor) TryLog1pI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Log1pI", C.Log1p_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Sqrt torch.sqrt
+func Sqrt(a Tensor) Tensor {
+	return a.Sqrt()
+}
+
+// Sqrt torch.sqrt
+func (a Tensor) Sqrt() Tensor {
+	return Must(TrySqrt(a))
+}
+
+// TrySqrt is Sqrt that returns an error rather than panics
+func TrySqrt(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Sqrt",
+		C.Sqrt(C.Tensor(*a.T), &t), &t, a)
+}
+
+// SqrtI computes torch.sqrt in-place
+func (a *Tensor) SqrtI() Tensor {
+	return Must(a.TrySqrtI())
+}
+
+// TrySqrtI is SqrtI that returns an error rather than panics
+func (a *Tensor) TrySqrtI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("SqrtI", C.Sqrt_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Rsqrt torch.rsqrt
+func Rsqrt(a Tensor) Tensor {
+	return a.Rsqrt()
+}
+
+// Rsqrt torch.rsqrt
+func (a Tensor) Rsqrt() Tensor {
+	return Must(TryRsqrt(a))
+}
+
+// TryRsqrt is Rsqrt that returns an error rather than panics
+func TryRsqrt(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Rsqrt",
+		C.Rsqrt(C.Tensor(*a.T), &t), &t, a)
+}
+
+// RsqrtI computes torch.rsqrt in-place
+func (a *Tensor) RsqrtI() Tensor {
+	return Must(a.TryRsqrtI())
+}
+
+// TryRsqrtI is RsqrtI that returns an error rather than panics
+func (a *Tensor) TryRsqrtI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("RsqrtI", C.Rsqrt_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Abs torch.abs.  It returns the magnitudes, a real-valued tensor, of a
+// complex tensor.
+func Abs(a Tensor) Tensor {
+	return a.Abs()
+}
+
+// Abs torch.abs
+func (a Tensor) Abs() Tensor {
+	return Must(TryAbs(a))
+}
+
+// TryAbs is Abs that returns an error rather than panics
+func TryAbs(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Abs",
+		C.Abs(C.Tensor(*a.T), &t), &t, a)
+}
+
+// AbsI computes torch.abs in-place
+func (a *Tensor) AbsI() Tensor {
+	return Must(a.TryAbsI())
+}
+
+// TryAbsI is AbsI that returns an error rather than panics
+func (a *Tensor) TryAbsI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("AbsI", C.Abs_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Neg torch.neg
+func Neg(a Tensor) Tensor {
+	return a.Neg()
+}
+
+// Neg torch.neg
+func (a Tensor) Neg() Tensor {
+	return Must(TryNeg(a))
+}
+
+// TryNeg is Neg that returns an error rather than panics
+func TryNeg(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Neg",
+		C.Neg(C.Tensor(*a.T), &t), &t, a)
+}
+
+// NegI computes torch.neg in-place
+func (a *Tensor) NegI() Tensor {
+	return Must(a.TryNegI())
+}
+
+// TryNegI is NegI that returns an error rather than panics
+func (a *Tensor) TryNegI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("NegI", C.Neg_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Sign torch.sign
+func Sign(a Tensor) Tensor {
+	return a.Sign()
+}
+
+// Sign torch.sign
+func (a Tensor) Sign() Tensor {
+	return Must(TrySign(a))
+}
+
+// TrySign is Sign that returns an error rather than panics
+func TrySign(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Sign",
+		C.Sign(C.Tensor(*a.T), &t), &t, a)
+}
+
+// SignI computes torch.sign in-place
+func (a *Tensor) SignI() Tensor {
+	return Must(a.TrySignI())
+}
+
+// TrySignI is SignI that returns an error rather than panics
+func (a *Tensor) TrySignI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("SignI", C.Sign_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Floor torch.floor
+func Floor(a Tensor) Tensor {
+	return a.Floor()
+}
+
+// Floor torch.floor
+func (a Tensor) Floor() Tensor {
+	return Must(TryFloor(a))
+}
+
+// TryFloor is Floor that returns an error rather than panics
+func TryFloor(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Floor",
+		C.Floor(C.Tensor(*a.T), &t), &t, a)
+}
+
+// FloorI computes torch.floor in-place
+func (a *Tensor) FloorI() Tensor {
+	return Must(a.TryFloorI())
+}
+
+// TryFloorI is FloorI that returns an error rather than panics
+func (a *Tensor) TryFloorI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("FloorI", C.Floor_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Ceil torch.ceil
+func Ceil(a Tensor) Tensor {
+	return a.Ceil()
+}
+
+// Ceil torch.ceil
+func (a Tensor) Ceil() Tensor {
+	return Must(TryCeil(a))
+}
+
+// TryCeil is Ceil that returns an error rather than panics
+func TryCeil(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Ceil",
+		C.Ceil(C.Tensor(*a.T), &t), &t, a)
+}
+
+// CeilI computes torch.ceil in-place
+func (a *Tensor) CeilI() Tensor {
+	return Must(a.TryCeilI())
+}
+
+// TryCeilI is CeilI that returns an error rather than panics
+func (a *Tensor) TryCeilI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("CeilI", C.Ceil_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Round torch.round
+func Round(a Tensor) Tensor {
+	return a.Round()
+}
+
+// Round torch.round
+func (a Tensor) Round() Tensor {
+	return Must(TryRound(a))
+}
+
+// TryRound is Round that returns an error rather than panics
+func TryRound(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Round",
+		C.Round(C.Tensor(*a.T), &t), &t, a)
+}
+
+// RoundI computes torch.round in-place
+func (a *Tensor) RoundI() Tensor {
+	return Must(a.TryRoundI())
+}
+
+// TryRoundI is RoundI that returns an error rather than panics
+func (a *Tensor) TryRoundI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("RoundI", C.Round_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Sin torch.sin
+func Sin(a Tensor) Tensor {
+	return a.Sin()
+}
+
+// Sin torch.sin
+func (a Tensor) Sin() Tensor {
+	return Must(TrySin(a))
+}
+
+// TrySin is Sin that returns an error rather than panics
+func TrySin(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Sin",
+		C.Sin(C.Tensor(*a.T), &t), &t, a)
+}
+
+// SinI computes torch.sin in-place
+func (a *Tensor) SinI() Tensor {
+	return Must(a.TrySinI())
+}
+
+// TrySinI is SinI that returns an error rather than panics
+func (a *Tensor) TrySinI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("SinI", C.Sin_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Cos torch.cos
+func Cos(a Tensor) Tensor {
+	return a.Cos()
+}
+
+// Cos torch.cos
+func (a Tensor) Cos() Tensor {
+	return Must(TryCos(a))
+}
+
+// TryCos is Cos that returns an error rather than panics
+func TryCos(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Cos",
+		C.Cos(C.Tensor(*a.T), &t), &t, a)
+}
+
+// CosI computes torch.cos in-place
+func (a *Tensor) CosI() Tensor {
+	return Must(a.TryCosI())
+}
+
+// TryCosI is CosI that returns an error rather than panics
+func (a *Tensor) TryCosI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("CosI", C.Cos_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// Reciprocal torch.reciprocal
+func Reciprocal(a Tensor) Tensor {
+	return a.Reciprocal()
+}
+
+// Reciprocal torch.reciprocal
+func (a Tensor) Reciprocal() Tensor {
+	return Must(TryReciprocal(a))
+}
+
+// TryReciprocal is Reciprocal that returns an error rather than panics
+func TryReciprocal(a Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Reciprocal",
+		C.Reciprocal(C.Tensor(*a.T), &t), &t, a)
+}
+
+// ReciprocalI computes torch.reciprocal in-place
+func (a *Tensor) ReciprocalI() Tensor {
+	return Must(a.TryReciprocalI())
+}
+
+// TryReciprocalI is ReciprocalI that returns an error rather than panics
+func (a *Tensor) TryReciprocalI() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ReciprocalI", C.Reciprocal_(C.Tensor(*a.T), &t), &t, *a)
+}
+
+// AddScalar adds a scalar to each element of a, broadcasting other like torch.add
+func AddScalar(a Tensor, other float64) Tensor {
+	return a.AddScalar(other)
+}
+
+// AddScalar adds a scalar to each element of a, broadcasting other like torch.add
+func (a Tensor) AddScalar(other float64) Tensor {
+	return Must(TryAddScalar(a, other))
+}
+
+// TryAddScalar is AddScalar that returns an error rather than panics
+func TryAddScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("AddScalar",
+		C.AddScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// AddScalarI is the in-place version of AddScalar
+func (a *Tensor) AddScalarI(other float64) Tensor {
+	return Must(a.TryAddScalarI(other))
+}
+
+// TryAddScalarI is AddScalarI that returns an error rather than panics
+func (a *Tensor) TryAddScalarI(other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("AddScalarI",
+		C.AddScalar_(C.Tensor(*a.T), C.double(other), &t), &t, *a)
+}
+
+// SubScalar subtracts a scalar from each element of a, broadcasting other like torch.sub
+func SubScalar(a Tensor, other float64) Tensor {
+	return a.SubScalar(other)
+}
+
+// SubScalar subtracts a scalar from each element of a, broadcasting other like torch.sub
+func (a Tensor) SubScalar(other float64) Tensor {
+	return Must(TrySubScalar(a, other))
+}
+
+// TrySubScalar is SubScalar that returns an error rather than panics
+func TrySubScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("SubScalar",
+		C.SubScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// SubScalarI is the in-place version of SubScalar
+func (a *Tensor) SubScalarI(other float64) Tensor {
+	return Must(a.TrySubScalarI(other))
+}
+
+// TrySubScalarI is SubScalarI that returns an error rather than panics
+func (a *Tensor) TrySubScalarI(other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("SubScalarI",
+		C.SubScalar_(C.Tensor(*a.T), C.double(other), &t), &t, *a)
+}
+
+// MulScalar multiplies each element of a, broadcasting other like torch.mul
+func MulScalar(a Tensor, other float64) Tensor {
+	return a.MulScalar(other)
+}
+
+// MulScalar multiplies each element of a, broadcasting other like torch.mul
+func (a Tensor) MulScalar(other float64) Tensor {
+	return Must(TryMulScalar(a, other))
+}
+
+// TryMulScalar is MulScalar that returns an error rather than panics
+func TryMulScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("MulScalar",
+		C.MulScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// MulScalarI is the in-place version of MulScalar
+func (a *Tensor) MulScalarI(other float64) Tensor {
+	return Must(a.TryMulScalarI(other))
+}
+
+// TryMulScalarI is MulScalarI that returns an error rather than panics
+func (a *Tensor) TryMulScalarI(other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("MulScalarI",
+		C.MulScalar_(C.Tensor(*a.T), C.double(other), &t), &t, *a)
+}
+
+// DivScalar divides each element of a, broadcasting other like torch.div
+func DivScalar(a Tensor, other float64) Tensor {
+	return a.DivScalar(other)
+}
+
+// DivScalar divides each element of a, broadcasting other like torch.div
+func (a Tensor) DivScalar(other float64) Tensor {
+	return Must(TryDivScalar(a, other))
+}
+
+// TryDivScalar is DivScalar that returns an error rather than panics
+func TryDivScalar(a Tensor, other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("DivScalar",
+		C.DivScalar(C.Tensor(*a.T), C.double(other), &t), &t, a)
+}
+
+// DivScalarI is the in-place version of DivScalar
+func (a *Tensor) DivScalarI(other float64) Tensor {
+	return Must(a.TryDivScalarI(other))
+}
+
+// TryDivScalarI is DivScalarI that returns an error rather than panics
+func (a *Tensor) TryDivScalarI(other float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("DivScalarI",
+		C.DivScalar_(C.Tensor(*a.T), C.double(other), &t), &t, *a)
+}
+
+// Pow torch.pow with a tensor exponent
+func Pow(a, exponent Tensor) Tensor {
+	return a.Pow(exponent)
+}
+
+// Pow torch.pow with a tensor exponent
+func (a Tensor) Pow(exponent Tensor) Tensor {
+	return Must(TryPow(a, exponent))
+}
+
+// TryPow is Pow that returns an error rather than panics
+func TryPow(a, exponent Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Pow",
+		C.Pow(C.Tensor(*a.T), C.Tensor(*exponent.T), &t), &t, a, exponent)
+}
+
+// PowI is the in-place version of Pow
+func (a *Tensor) PowI(exponent Tensor) Tensor {
+	return Must(a.TryPowI(exponent))
+}
+
+// TryPowI is PowI that returns an error rather than panics
+func (a *Tensor) TryPowI(exponent Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("PowI",
+		C.Pow_(C.Tensor(*a.T), C.Tensor(*exponent.T), &t), &t, *a, exponent)
+}
+
+// PowScalar torch.pow with a scalar exponent
+func PowScalar(a Tensor, exponent float64) Tensor {
+	return a.PowScalar(exponent)
+}
+
+// PowScalar torch.pow with a scalar exponent
+func (a Tensor) PowScalar(exponent float64) Tensor {
+	return Must(TryPowScalar(a, exponent))
+}
+
+// TryPowScalar is PowScalar that returns an error rather than panics
+func TryPowScalar(a Tensor, exponent float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("PowScalar",
+		C.PowScalar(C.Tensor(*a.T), C.double(exponent), &t), &t, a)
+}
+
+// PowScalarI is the in-place version of PowScalar
+func (a *Tensor) PowScalarI(exponent float64) Tensor {
+	return Must(a.TryPowScalarI(exponent))
+}
+
+// TryPowScalarI is PowScalarI that returns an error rather than panics
+func (a *Tensor) TryPowScalarI(exponent float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("PowScalarI",
+		C.PowScalar_(C.Tensor(*a.T), C.double(exponent), &t), &t, *a)
+}
+
+// Clamp torch.clamp
+func Clamp(a Tensor, min, max float64) Tensor {
+	return a.Clamp(min, max)
+}
+
+// Clamp torch.clamp
+func (a Tensor) Clamp(min, max float64) Tensor {
+	return Must(TryClamp(a, min, max))
+}
+
+// TryClamp is Clamp that returns an error rather than panics
+func TryClamp(a Tensor, min, max float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Clamp",
+		C.Clamp(C.Tensor(*a.T), C.double(min), C.double(max), &t), &t, a)
+}
+
+// ClampI is the in-place version of Clamp
+func (a *Tensor) ClampI(min, max float64) Tensor {
+	return Must(a.TryClampI(min, max))
+}
+
+// TryClampI is ClampI that returns an error rather than panics
+func (a *Tensor) TryClampI(min, max float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ClampI",
+		C.Clamp_(C.Tensor(*a.T), C.double(min), C.double(max), &t), &t, *a)
+}
+
+// Clip torch.clip, an alias of Clamp
+func Clip(a Tensor, min, max float64) Tensor {
+	return a.Clamp(min, max)
+}
+
+// Clip torch.clip, an alias of Clamp
+func (a Tensor) Clip(min, max float64) Tensor {
+	return a.Clamp(min, max)
+}
+
+// Maximum torch.maximum, the element-wise maximum of a and other
+func Maximum(a, other Tensor) Tensor {
+	return Must(TryMaximum(a, other))
+}
+
+// TryMaximum is Maximum that returns an error rather than panics
+func TryMaximum(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Maximum",
+		C.Maximum(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// Maximum torch.maximum
+func (a Tensor) Maximum(other Tensor) Tensor {
+	return Maximum(a, other)
+}
+
+// Minimum torch.minimum, the element-wise minimum of a and other
+func Minimum(a, other Tensor) Tensor {
+	return Must(TryMinimum(a, other))
+}
+
+// TryMinimum is Minimum that returns an error rather than panics
+func TryMinimum(a, other Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Minimum",
+		C.Minimum(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
+}
+
+// Minimum torch.minimum
+func (a Tensor) Minimum(other Tensor) Tensor {
+	return Minimum(a, other)
+}
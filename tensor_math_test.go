@@ -0,0 +1,98 @@
+package gotorch_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestUnaryMath(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 4, 9})
+	a.True(torch.AllClose(torch.NewTensor([]float32{1, 2, 3}), x.Sqrt()))
+	a.True(torch.AllClose(torch.NewTensor([]float32{1, 0.5, 1.0 / 3}), x.Rsqrt()))
+	a.True(torch.AllClose(torch.NewTensor([]float32{1, 0.25, 1.0 / 9}), torch.Reciprocal(x)))
+	a.True(torch.AllClose(x, torch.Exp(torch.Log(x))))
+	a.True(torch.AllClose(torch.Log(x.AddScalar(1)), x.Log1p()))
+
+	y := torch.NewTensor([]float32{-1.5, 0, 2.5})
+	a.Equal([]float32{1.5, 0, 2.5}, y.Abs().Float32s())
+	a.Equal([]float32{1.5, 0, -2.5}, y.Neg().Float32s())
+	a.Equal([]float32{-1, 0, 1}, y.Sign().Float32s())
+	a.Equal([]float32{-2, 0, 2}, y.Floor().Float32s())
+	a.Equal([]float32{-1, 0, 3}, y.Ceil().Float32s())
+	a.Equal([]float32{-2, 0, 2}, y.Round().Float32s())
+
+	z := torch.NewTensor([]float64{0, math.Pi / 2})
+	a.True(torch.AllClose(torch.NewTensor([]float64{0, 1}), z.Sin()))
+	a.True(torch.AllClose(torch.NewTensor([]float64{1, 0}), z.Cos()))
+}
+
+func TestUnaryMathI(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 4, 9})
+	x.SqrtI()
+	a.Equal([]float32{1, 2, 3}, x.Float32s())
+	x.NegI()
+	a.Equal([]float32{-1, -2, -3}, x.Float32s())
+	x.AbsI()
+	a.Equal([]float32{1, 2, 3}, x.Float32s())
+}
+
+func TestScalarArith(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+	a.Equal([][]float32{{3, 4}, {5, 6}}, x.AddScalar(2).ToSlice())
+	a.Equal([][]float32{{0, 1}, {2, 3}}, torch.SubScalar(x, 1).ToSlice())
+	a.Equal([][]float32{{2, 4}, {6, 8}}, x.MulScalar(2).ToSlice())
+	a.Equal([][]float32{{0.5, 1}, {1.5, 2}}, x.DivScalar(2).ToSlice())
+
+	x.MulScalarI(10)
+	a.Equal([][]float32{{10, 20}, {30, 40}}, x.ToSlice())
+	x.DivScalarI(10)
+	x.AddScalarI(1)
+	x.SubScalarI(2)
+	a.Equal([][]float32{{0, 1}, {2, 3}}, x.ToSlice())
+}
+
+func TestPow(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 2, 3})
+	a.Equal([]float32{1, 4, 9}, x.PowScalar(2).Float32s())
+	// Broadcast the exponent.
+	e := torch.NewTensor([][]float32{{1}, {3}})
+	a.Equal([][]float32{{1, 2, 3}, {1, 8, 27}}, torch.Pow(x, e).ToSlice())
+	x.PowI(torch.NewTensor([]float32{2, 2, 2}))
+	a.Equal([]float32{1, 4, 9}, x.Float32s())
+	x.PowScalarI(0.5)
+	a.Equal([]float32{1, 2, 3}, x.Float32s())
+}
+
+func TestClampMaximumMinimum(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{-2, 0.5, 3})
+	a.Equal([]float32{-1, 0.5, 1}, x.Clamp(-1, 1).Float32s())
+	a.Equal([]float32{0, 0.5, 2}, torch.Clip(x, 0, 2).Float32s())
+	x.ClampI(0, 1)
+	a.Equal([]float32{0, 0.5, 1}, x.Float32s())
+
+	y := torch.NewTensor([]float32{1, 0, 0.5})
+	a.Equal([]float32{1, 0.5, 1}, torch.Maximum(x, y).Float32s())
+	a.Equal([]float32{0, 0, 0.5}, x.Minimum(y).Float32s())
+}
+
+func TestTryMath(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 2})
+	y, e := torch.TryExp(x)
+	a.NoError(e)
+	a.True(torch.AllClose(x.Exp(), y))
+
+	_, e = torch.TryPow(x, torch.NewTensor([]float32{1, 2, 3}))
+	a.Error(e)
+	_, e = torch.TryMaximum(x, torch.NewTensor([]float32{1, 2, 3}))
+	a.Error(e)
+	a.Panics(func() { torch.Minimum(x, torch.NewTensor([]float32{1, 2, 3})) })
+}
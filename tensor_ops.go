@@ -11,17 +11,18 @@ import (
 	"reflect"
 	"strings"
 	"unsafe"
-
-	"github.com/wangkuiyi/gotorch/variadic"
 )
 
 // Add torch.add
 func Add(a, other Tensor, alpha float32) Tensor {
+	return Must(TryAdd(a, other, alpha))
+}
+
+// TryAdd is Add that returns an error rather than panics
+func TryAdd(a, other Tensor, alpha float32) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Add(C.Tensor(*a.T), C.Tensor(*other.T),
-		C.float(alpha), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Add", C.Add(C.Tensor(*a.T), C.Tensor(*other.T),
+		C.float(alpha), &t), &t, a, other)
 }
 
 // Add torch.add
@@ -31,23 +32,26 @@ func (a *Tensor) Add(other Tensor, alpha float32) Tensor {
 
 // AddI adds in-place
 func (a *Tensor) AddI(other Tensor, alpha float32) Tensor {
+	return Must(a.TryAddI(other, alpha))
+}
+
+// TryAddI is AddI that returns an error rather than panics
+func (a *Tensor) TryAddI(other Tensor, alpha float32) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Add_(
-		C.Tensor(*a.T),
-		C.Tensor(*other.T),
-		C.float(alpha),
-		&t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("AddI", C.Add_(C.Tensor(*a.T), C.Tensor(*other.T),
+		C.float(alpha), &t), &t, *a, other)
 }
 
 // Sub torch.sub
 func Sub(a, other Tensor, alpha float32) Tensor {
+	return Must(TrySub(a, other, alpha))
+}
+
+// TrySub is Sub that returns an error rather than panics
+func TrySub(a, other Tensor, alpha float32) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Sub(C.Tensor(*a.T), C.Tensor(*other.T),
-		C.float(alpha), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Sub", C.Sub(C.Tensor(*a.T), C.Tensor(*other.T),
+		C.float(alpha), &t), &t, a, other)
 }
 
 // Sub torch.sub
@@ -57,22 +61,26 @@ func (a *Tensor) Sub(other Tensor, alpha float32) Tensor {
 
 // SubI subs in-place
 func (a *Tensor) SubI(other Tensor, alpha float32) Tensor {
+	return Must(a.TrySubI(other, alpha))
+}
+
+// TrySubI is SubI that returns an error rather than panics
+func (a *Tensor) TrySubI(other Tensor, alpha float32) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Sub_(
-		C.Tensor(*a.T),
-		C.Tensor(*other.T),
-		C.float(alpha),
-		&t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("SubI", C.Sub_(C.Tensor(*a.T), C.Tensor(*other.T),
+		C.float(alpha), &t), &t, *a, other)
 }
 
 // Mul torch.mul
 func Mul(a, other Tensor) Tensor {
+	return Must(TryMul(a, other))
+}
+
+// TryMul is Mul that returns an error rather than panics
+func TryMul(a, other Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Mul(C.Tensor(*a.T), C.Tensor(*other.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Mul",
+		C.Mul(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
 }
 
 // Mul torch.Mul
@@ -82,21 +90,26 @@ func (a *Tensor) Mul(other Tensor) Tensor {
 
 // MulI multiplies in-place
 func (a *Tensor) MulI(other Tensor) Tensor {
+	return Must(a.TryMulI(other))
+}
+
+// TryMulI is MulI that returns an error rather than panics
+func (a *Tensor) TryMulI(other Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Mul_(
-		C.Tensor(*a.T),
-		C.Tensor(*other.T),
-		&t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("MulI",
+		C.Mul_(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, *a, other)
 }
 
 // Div torch.div
 func Div(a, other Tensor) Tensor {
+	return Must(TryDiv(a, other))
+}
+
+// TryDiv is Div that returns an error rather than panics
+func TryDiv(a, other Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Div(C.Tensor(*a.T), C.Tensor(*other.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Div",
+		C.Div(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
 }
 
 // Div torch.Div
@@ -106,30 +119,39 @@ func (a *Tensor) Div(other Tensor) Tensor {
 
 // DivI run divides in-place
 func (a *Tensor) DivI(other Tensor) Tensor {
+	return Must(a.TryDivI(other))
+}
+
+// TryDivI is DivI that returns an error rather than panics
+func (a *Tensor) TryDivI(other Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Div_(
-		C.Tensor(*a.T),
-		C.Tensor(*other.T),
-		&t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("DivI",
+		C.Div_(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, *a, other)
 }
 
 // Permute transpose the tensor dims.
 func (a *Tensor) Permute(dims []int64) Tensor {
+	return Must(TryPermute(*a, dims))
+}
+
+// TryPermute is Tensor.Permute that returns an error rather than panics
+func TryPermute(a Tensor, dims []int64) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Permute(C.Tensor(*a.T), (*C.int64_t)(&dims[0]), C.int64_t(len(dims)), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Permute", C.Permute(C.Tensor(*a.T),
+		dimsPtr(dims), C.int64_t(len(dims)), &t), &t, a)
 }
 
 // Eq wraps torch.eq, which does element-wise comparison between two tensors and returns
 // a tensor of the same size as the operands.
 func Eq(a, other Tensor) Tensor {
+	return Must(TryEq(a, other))
+}
+
+// TryEq is Eq that returns an error rather than panics
+func TryEq(a, other Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Eq(C.Tensor(*a.T), C.Tensor(*other.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Eq",
+		C.Eq(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
 }
 
 // Eq torch.eq
@@ -153,10 +175,14 @@ func AllClose(a, b Tensor) bool {
 
 // ExpandAs torch.expand_as
 func ExpandAs(a, other Tensor) Tensor {
+	return Must(TryExpandAs(a, other))
+}
+
+// TryExpandAs is ExpandAs that returns an error rather than panics
+func TryExpandAs(a, other Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.ExpandAs(C.Tensor(*a.T), C.Tensor(*other.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("ExpandAs",
+		C.ExpandAs(C.Tensor(*a.T), C.Tensor(*other.T), &t), &t, a, other)
 }
 
 // ExpandAs torch.expand_as
@@ -166,18 +192,26 @@ func (a Tensor) ExpandAs(other Tensor) Tensor {
 
 // Flatten torch.flatten
 func Flatten(a Tensor, startDim, endDim int64) Tensor {
+	return Must(TryFlatten(a, startDim, endDim))
+}
+
+// TryFlatten is Flatten that returns an error rather than panics
+func TryFlatten(a Tensor, startDim, endDim int64) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Flatten(C.Tensor(*a.T), C.int64_t(startDim), C.int64_t(endDim), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Flatten", C.Flatten(C.Tensor(*a.T),
+		C.int64_t(startDim), C.int64_t(endDim), &t), &t, a)
 }
 
 // IndexSelect torch.index_select
 func IndexSelect(a Tensor, dim int64, index Tensor) Tensor {
+	return Must(TryIndexSelect(a, dim, index))
+}
+
+// TryIndexSelect is IndexSelect that returns an error rather than panics
+func TryIndexSelect(a Tensor, dim int64, index Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.IndexSelect(C.Tensor(*a.T), C.int64_t(dim), C.Tensor(*index.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("IndexSelect", C.IndexSelect(C.Tensor(*a.T),
+		C.int64_t(dim), C.Tensor(*index.T), &t), &t, a, index)
 }
 
 // IndexSelect torch.index_select
@@ -209,17 +243,25 @@ func (a Tensor) Item() interface{} {
 		case Long:
 			return v
 		}
-	case Half, Float, Double:
+	case Half, BFloat16, Float, Double:
 		var v float64
 		MustNil(unsafe.Pointer(C.ItemFloat64(C.Tensor(*a.T), (*C.double)(&v))))
 		switch dtype {
-		case Half, Float:
+		case Half, BFloat16, Float:
 			return float32(v)
 		case Double:
 			return v
 		}
+	case ComplexFloat, ComplexDouble:
+		var re, im float64
+		MustNil(unsafe.Pointer(C.ItemComplex128(C.Tensor(*a.T),
+			(*C.double)(&re), (*C.double)(&im))))
+		if dtype == ComplexFloat {
+			return complex64(complex(re, im))
+		}
+		return complex(re, im)
 	}
-	log.Panicf("DType %d not supported now.", a.Dtype())
+	log.Panicf("Dtype %v not supported now.", a.Dtype())
 	return nil
 }
 
@@ -230,10 +272,14 @@ func LeakyRelu(t Tensor, negativeSlope float64) Tensor {
 
 // LeakyRelu returns leaky relu of the tensor according to negativeSlope
 func (a *Tensor) LeakyRelu(negativeSlope float64) Tensor {
+	return Must(TryLeakyRelu(*a, negativeSlope))
+}
+
+// TryLeakyRelu is LeakyRelu that returns an error rather than panics
+func TryLeakyRelu(a Tensor, negativeSlope float64) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.LeakyRelu(C.Tensor(*a.T), C.double(negativeSlope), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("LeakyRelu",
+		C.LeakyRelu(C.Tensor(*a.T), C.double(negativeSlope), &t), &t, a)
 }
 
 // LogSoftmax returns log softmax of the input tensor
@@ -243,39 +289,61 @@ func LogSoftmax(t Tensor, dim int64) Tensor {
 
 // LogSoftmax returns log softmax of the current tensor
 func (a Tensor) LogSoftmax(dim int64) Tensor {
+	return Must(TryLogSoftmax(a, dim))
+}
+
+// TryLogSoftmax is LogSoftmax that returns an error rather than panics
+func TryLogSoftmax(a Tensor, dim int64) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.LogSoftmax(C.Tensor(*a.T), C.int64_t(dim), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("LogSoftmax",
+		C.LogSoftmax(C.Tensor(*a.T), C.int64_t(dim), &t), &t, a)
 }
 
-// Mean returns mean of the current tensor
-func Mean(t Tensor) Tensor {
-	return t.Mean()
+// Mean returns mean of the current tensor.  It takes the same options as Sum.
+func Mean(t Tensor, opt ...map[string]interface{}) Tensor {
+	return t.Mean(opt...)
 }
 
 // Mean torch.mean
-func (a Tensor) Mean() Tensor {
+func (a Tensor) Mean(opt ...map[string]interface{}) Tensor {
+	return Must(TryMean(a, opt...))
+}
+
+// TryMean is Mean that returns an error rather than panics
+func TryMean(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Mean", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Mean(C.Tensor(*a.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	if len(dims) > 0 {
+		return newTensorOrError("Mean", C.MeanByDims(C.Tensor(*a.T),
+			dimsPtr(dims), C.int64_t(len(dims)), boolToInt8(keepDim), &t), &t, a)
+	}
+	return newTensorOrError("Mean", C.Mean(C.Tensor(*a.T), &t), &t, a)
 }
 
 // MM multiplies each element of the input two tensors
 func MM(a, b Tensor) Tensor {
+	return Must(TryMM(a, b))
+}
+
+// TryMM is MM that returns an error rather than panics
+func TryMM(a, b Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.MM(C.Tensor(*a.T), C.Tensor(*b.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("MM",
+		C.MM(C.Tensor(*a.T), C.Tensor(*b.T), &t), &t, a, b)
 }
 
 // Relu returns relu of the tensor
 func (a *Tensor) Relu() Tensor {
+	return Must(TryRelu(*a))
+}
+
+// TryRelu is Relu that returns an error rather than panics
+func TryRelu(a Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Relu(C.Tensor(*a.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Relu", C.Relu(C.Tensor(*a.T), &t), &t, a)
 }
 
 // Relu returns relu of the tensor
@@ -290,23 +358,26 @@ func Sigmoid(t Tensor) Tensor {
 
 // Sigmoid returns sigmoid of the current tensor
 func (a Tensor) Sigmoid() Tensor {
+	return Must(TrySigmoid(a))
+}
+
+// TrySigmoid is Sigmoid that returns an error rather than panics
+func TrySigmoid(a Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Sigmoid(C.Tensor(*a.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Sigmoid", C.Sigmoid(C.Tensor(*a.T), &t), &t, a)
 }
 
 // Stack concatenates sequence of tensors along a new dimension
 func Stack(tensors []Tensor, dim int64) Tensor {
-	CT := []C.Tensor{}
-	for _, t := range tensors {
-		CT = append(CT, C.Tensor(*t.T))
-	}
-	p := (*C.Tensor)(unsafe.Pointer(&CT[0]))
+	return Must(TryStack(tensors, dim))
+}
+
+// TryStack is Stack that returns an error rather than panics
+func TryStack(tensors []Tensor, dim int64) (Tensor, error) {
+	CT := cTensors(tensors)
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Stack(p, C.int64_t(len(CT)), C.int64_t(dim), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Stack",
+		C.Stack(&CT[0], C.int64_t(len(tensors)), C.int64_t(dim), &t), &t, tensors...)
 }
 
 // Squeeze torch.squeeze
@@ -316,41 +387,46 @@ func Squeeze(t Tensor, dim ...int64) Tensor {
 
 // Squeeze tensor.squeeze
 func (a Tensor) Squeeze(dim ...int64) Tensor {
+	return Must(TrySqueeze(a, dim...))
+}
+
+// TrySqueeze is Squeeze that returns an error rather than panics
+func TrySqueeze(a Tensor, dim ...int64) (Tensor, error) {
 	var t C.Tensor
 	switch len(dim) {
 	case 0:
-		MustNil(unsafe.Pointer(C.Squeeze(C.Tensor(*a.T), &t)))
-		SetTensorFinalizer((*unsafe.Pointer)(&t))
-		return Tensor{(*unsafe.Pointer)(&t)}
+		return newTensorOrError("Squeeze", C.Squeeze(C.Tensor(*a.T), &t), &t, a)
 	case 1:
-		MustNil(unsafe.Pointer(C.SqueezeWithDim(C.Tensor(*a.T), C.int64_t(dim[0]), &t)))
-		SetTensorFinalizer((*unsafe.Pointer)(&t))
-		return Tensor{(*unsafe.Pointer)(&t)}
-	default:
-		panic("Squeeze only accepts 0-1 dim as input")
+		return newTensorOrError("Squeeze",
+			C.SqueezeWithDim(C.Tensor(*a.T), C.int64_t(dim[0]), &t), &t, a)
 	}
+	return Tensor{}, &TorchError{Op: "Squeeze",
+		Shapes:  [][]int64{a.Shape()},
+		Dtypes:  []Dtype{a.Dtype()},
+		Message: "Squeeze only accepts 0-1 dim as input"}
 }
 
-// Sum is torch.sum
+// Sum is torch.sum.  The option "dim" could be an integer or a []int64, and
+// the option "keepDim" defaults to false.
 func Sum(a Tensor, opt ...map[string]interface{}) Tensor {
-	if variadic.Has(opt, "dim") {
-		dim := variadic.Get(opt, "dim").(int)
-		keepDim := variadic.Get(opt, "keepDim", false).(bool)
+	return Must(TrySum(a, opt...))
+}
 
-		k := 0
-		if keepDim {
-			k = 1
-		}
-		var t C.Tensor
-		MustNil(unsafe.Pointer(C.SumByDim(C.Tensor(*a.T), C.int64_t(dim), C.int8_t(k), &t)))
-		SetTensorFinalizer((*unsafe.Pointer)(&t))
-		return Tensor{(*unsafe.Pointer)(&t)}
+// TrySum is Sum that returns an error rather than panics
+func TrySum(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Sum", a, opt)
+	if err != nil {
+		return Tensor{}, err
 	}
-
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Sum(C.Tensor(*a.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	if len(dims) == 1 {
+		return newTensorOrError("Sum", C.SumByDim(C.Tensor(*a.T),
+			C.int64_t(dims[0]), boolToInt8(keepDim), &t), &t, a)
+	} else if len(dims) > 1 {
+		return newTensorOrError("Sum", C.SumByDims(C.Tensor(*a.T),
+			dimsPtr(dims), C.int64_t(len(dims)), boolToInt8(keepDim), &t), &t, a)
+	}
+	return newTensorOrError("Sum", C.Sum(C.Tensor(*a.T), &t), &t, a)
 }
 
 // Sum is Tensor.sum
@@ -365,10 +441,13 @@ func Tanh(t Tensor) Tensor {
 
 // Tanh returns tanh of the current tensor
 func (a Tensor) Tanh() Tensor {
+	return Must(TryTanh(a))
+}
+
+// TryTanh is Tanh that returns an error rather than panics
+func TryTanh(a Tensor) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Tanh(C.Tensor(*a.T), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Tanh", C.Tanh(C.Tensor(*a.T), &t), &t, a)
 }
 
 // TopK torch.topk
@@ -391,10 +470,14 @@ func TopK(a Tensor, k, dim int64, largest, sorted bool) (Tensor, Tensor) {
 
 // Transpose torch.transpose
 func Transpose(a Tensor, dim0, dim1 int64) Tensor {
+	return Must(TryTranspose(a, dim0, dim1))
+}
+
+// TryTranspose is Transpose that returns an error rather than panics
+func TryTranspose(a Tensor, dim0, dim1 int64) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.Transpose(C.Tensor(*a.T), C.int64_t(dim0), C.int64_t(dim1), &t)))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("Transpose", C.Transpose(C.Tensor(*a.T),
+		C.int64_t(dim0), C.int64_t(dim1), &t), &t, a)
 }
 
 // Transpose torch.transpose
@@ -404,10 +487,14 @@ func (a Tensor) Transpose(dim0, dim1 int64) Tensor {
 
 // View returns a new Tensor with the same data but of a different shape
 func View(a Tensor, shape ...int64) Tensor {
+	return Must(TryView(a, shape...))
+}
+
+// TryView is View that returns an error rather than panics
+func TryView(a Tensor, shape ...int64) (Tensor, error) {
 	var t C.Tensor
-	MustNil(unsafe.Pointer(C.View(C.Tensor(*a.T), &t, (*C.int64_t)(unsafe.Pointer(&shape[0])), C.int64_t(len(shape)))))
-	SetTensorFinalizer((*unsafe.Pointer)(&t))
-	return Tensor{(*unsafe.Pointer)(&t)}
+	return newTensorOrError("View", C.View(C.Tensor(*a.T), &t,
+		dimsPtr(shape), C.int64_t(len(shape))), &t, a)
 }
 
 // View returns a new Tensor with the same data but of a different shape
@@ -0,0 +1,168 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Quantized tensors of dtype QInt8, QUInt8, or QInt32 keep integers q and
+// represent the real values (q - zeroPoint) * scale.  Tensors quantized per
+// tensor have a scale and a zero point, and those quantized per channel have
+// a scale and a zero point for each slice along an axis.  Quantized tensors
+// work on CPU only.
+
+// QuantizePerTensor torch.quantize_per_tensor quantizes the float tensor a
+func QuantizePerTensor(a Tensor, scale float64, zeroPoint int64, dtype Dtype) Tensor {
+	return Must(TryQuantizePerTensor(a, scale, zeroPoint, dtype))
+}
+
+// TryQuantizePerTensor is QuantizePerTensor that returns an error rather than
+// panics
+func TryQuantizePerTensor(a Tensor, scale float64, zeroPoint int64,
+	dtype Dtype) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("QuantizePerTensor", C.QuantizePerTensor(
+		C.Tensor(*a.T), C.double(scale), C.int64_t(zeroPoint), C.int8_t(dtype),
+		&t), &t, a)
+}
+
+// QuantizePerChannel torch.quantize_per_channel quantizes the float tensor a
+// with a scale and a zero point for each slice along axis.  scales is a
+// Double tensor and zeroPoints is a Long tensor.
+func QuantizePerChannel(a, scales, zeroPoints Tensor, axis int64, dtype Dtype) Tensor {
+	return Must(TryQuantizePerChannel(a, scales, zeroPoints, axis, dtype))
+}
+
+// TryQuantizePerChannel is QuantizePerChannel that returns an error rather
+// than panics
+func TryQuantizePerChannel(a, scales, zeroPoints Tensor, axis int64,
+	dtype Dtype) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("QuantizePerChannel", C.QuantizePerChannel(
+		C.Tensor(*a.T), C.Tensor(*scales.T), C.Tensor(*zeroPoints.T),
+		C.int64_t(axis), C.int8_t(dtype), &t), &t, a, scales, zeroPoints)
+}
+
+// Dequantize torch.Tensor.dequantize returns the Float tensor represented by
+// the quantized tensor a
+func (a Tensor) Dequantize() Tensor {
+	return Must(a.TryDequantize())
+}
+
+// TryDequantize is Dequantize that returns an error rather than panics
+func (a Tensor) TryDequantize() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Dequantize", C.Dequantize(C.Tensor(*a.T), &t), &t, a)
+}
+
+// IntRepr torch.Tensor.int_repr returns the integers in the quantized tensor
+// a.  The dtype is Char for QInt8, Byte for QUInt8, and Int for QInt32.
+func (a Tensor) IntRepr() Tensor {
+	return Must(a.TryIntRepr())
+}
+
+// TryIntRepr is IntRepr that returns an error rather than panics
+func (a Tensor) TryIntRepr() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("IntRepr", C.IntRepr(C.Tensor(*a.T), &t), &t, a)
+}
+
+// IsQuantized returns true if a is a quantized tensor
+func (a Tensor) IsQuantized() bool {
+	r, e := a.TryIsQuantized()
+	if e != nil {
+		panic(e)
+	}
+	return r
+}
+
+// TryIsQuantized is IsQuantized that returns an error rather than panics
+func (a Tensor) TryIsQuantized() (bool, error) {
+	var r C.int8_t
+	e := CheckNil("IsQuantized",
+		unsafe.Pointer(C.Tensor_IsQuantized(C.Tensor(*a.T), &r)), a)
+	return r != 0, e
+}
+
+// QScale returns the scale of a tensor quantized per tensor
+func (a Tensor) QScale() float64 {
+	r, e := a.TryQScale()
+	if e != nil {
+		panic(e)
+	}
+	return r
+}
+
+// TryQScale is QScale that returns an error rather than panics
+func (a Tensor) TryQScale() (float64, error) {
+	var r C.double
+	e := CheckNil("QScale", unsafe.Pointer(C.Tensor_QScale(C.Tensor(*a.T), &r)), a)
+	return float64(r), e
+}
+
+// QZeroPoint returns the zero point of a tensor quantized per tensor
+func (a Tensor) QZeroPoint() int64 {
+	r, e := a.TryQZeroPoint()
+	if e != nil {
+		panic(e)
+	}
+	return r
+}
+
+// TryQZeroPoint is QZeroPoint that returns an error rather than panics
+func (a Tensor) TryQZeroPoint() (int64, error) {
+	var r C.int64_t
+	e := CheckNil("QZeroPoint",
+		unsafe.Pointer(C.Tensor_QZeroPoint(C.Tensor(*a.T), &r)), a)
+	return int64(r), e
+}
+
+// QPerChannelScales returns the scales of a tensor quantized per channel
+func (a Tensor) QPerChannelScales() Tensor {
+	return Must(a.TryQPerChannelScales())
+}
+
+// TryQPerChannelScales is QPerChannelScales that returns an error rather than
+// panics
+func (a Tensor) TryQPerChannelScales() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("QPerChannelScales",
+		C.Tensor_QPerChannelScales(C.Tensor(*a.T), &t), &t, a)
+}
+
+// QPerChannelZeroPoints returns the zero points of a tensor quantized per
+// channel
+func (a Tensor) QPerChannelZeroPoints() Tensor {
+	return Must(a.TryQPerChannelZeroPoints())
+}
+
+// TryQPerChannelZeroPoints is QPerChannelZeroPoints that returns an error
+// rather than panics
+func (a Tensor) TryQPerChannelZeroPoints() (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("QPerChannelZeroPoints",
+		C.Tensor_QPerChannelZeroPoints(C.Tensor(*a.T), &t), &t, a)
+}
+
+// QPerChannelAxis returns the axis of a tensor quantized per channel
+func (a Tensor) QPerChannelAxis() int64 {
+	r, e := a.TryQPerChannelAxis()
+	if e != nil {
+		panic(e)
+	}
+	return r
+}
+
+// TryQPerChannelAxis is QPerChannelAxis that returns an error rather than
+// panics
+func (a Tensor) TryQPerChannelAxis() (int64, error) {
+	var r C.int64_t
+	e := CheckNil("QPerChannelAxis",
+		unsafe.Pointer(C.Tensor_QPerChannelAxis(C.Tensor(*a.T), &r)), a)
+	return int64(r), e
+}
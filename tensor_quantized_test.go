@@ -0,0 +1,48 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestQuantizePerTensor(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{-1, 0, 0.5, 1})
+	q := torch.QuantizePerTensor(x, 0.5, 2, torch.QUInt8)
+	a.True(q.IsQuantized())
+	a.False(x.IsQuantized())
+	a.Equal(torch.QUInt8, q.Dtype())
+	a.Equal(0.5, q.QScale())
+	a.Equal(int64(2), q.QZeroPoint())
+	a.Equal([]uint8{0, 2, 3, 4}, q.IntRepr().Uint8s())
+	a.Equal([]float32{-1, 0, 0.5, 1}, q.Dequantize().Float32s())
+
+	// Values out of the range of QInt8 saturate
+	q = torch.QuantizePerTensor(x.MulScalar(1000), 1, 0, torch.QInt8)
+	a.Equal([]int8{-128, 0, 127, 127}, q.IntRepr().Int8s())
+
+	_, e := torch.TryQuantizePerTensor(x, 1, 0, torch.Float)
+	a.Error(e)
+}
+
+func TestQuantizePerChannel(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {10, 20}})
+	q := torch.QuantizePerChannel(x, torch.NewTensor([]float64{1, 10}),
+		torch.NewTensor([]int64{0, 1}), 0, torch.QInt8)
+	a.Equal(int64(0), q.QPerChannelAxis())
+	a.Equal([]float64{1, 10}, q.QPerChannelScales().Float64s())
+	a.Equal([]int64{0, 1}, q.QPerChannelZeroPoints().Int64s())
+	a.Equal([]int8{1, 2, 2, 3}, q.IntRepr().Int8s())
+	a.Equal([]float32{1, 2, 10, 20}, q.Dequantize().Float32s())
+	a.Panics(func() { q.QScale() })
+
+	_, e := q.TryQScale()
+	a.Equal("QScale", e.(*torch.TorchError).Op)
+	_, e = x.TryQPerChannelAxis()
+	a.Equal("QPerChannelAxis", e.(*torch.TorchError).Op)
+	_, e = x.TryIntRepr()
+	a.Equal("IntRepr", e.(*torch.TorchError).Op)
+}
@@ -0,0 +1,419 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"unsafe"
+
+	"github.com/wangkuiyi/gotorch/variadic"
+)
+
+// Reductions follow the option convention of Sum.  Without the option "dim",
+// they reduce all elements into a 0-dim tensor.  The option "dim" could be an
+// integer or a []int64, and the option "keepDim" defaults to false.  For
+// example,
+//
+//	x.Mean(map[string]interface{}{"dim": []int64{2, 3}, "keepDim": true})
+
+// reductionDims returns the dimensions and keepDim specified in opt, or the
+// *TorchError of op on a if these options have wrong types.  dims is nil if
+// opt doesn't specify "dim".
+func reductionDims(op string, a Tensor, opt []map[string]interface{}) (
+	dims []int64, keepDim bool, err error) {
+	if d, ok := variadic.Lookup(opt, "dim"); ok {
+		if ds, ok := d.([]int64); ok {
+			dims = ds
+		} else {
+			v := reflect.ValueOf(d)
+			switch v.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				dims = []int64{v.Int()}
+			default:
+				return nil, false, newTorchError(op, fmt.Sprintf(
+					"the option dim must be an integer or []int64, got %T", d), a)
+			}
+		}
+	}
+	if k, ok := variadic.Lookup(opt, "keepDim"); ok {
+		if keepDim, ok = k.(bool); !ok {
+			return nil, false, newTorchError(op, fmt.Sprintf(
+				"the option keepDim must be a bool, got %T", k), a)
+		}
+	}
+	return dims, keepDim, nil
+}
+
+// reduceDims reduces a along each of dims by calling f, which reduces one
+// dimension, for reductions that libtorch supports only along one dimension.
+// It goes from the last dimension to the first so that the remaining
+// dimensions keep their indices when keepDim is false.  Dimensions out of
+// range are passed to f as they are for libtorch to report the error.
+func reduceDims(op string, a Tensor, dims []int64, keepDim bool,
+	f func(a Tensor, dim int64, keepDim bool) (Tensor, error)) (Tensor, error) {
+	n := a.Dim()
+	ds := make([]int64, len(dims))
+	seen := make(map[int64]bool)
+	for i, d := range dims {
+		if d < 0 && d >= -n {
+			d += n
+		}
+		if seen[d] {
+			return Tensor{}, newTorchError(op, fmt.Sprintf(
+				"dim %d appears multiple times in %v", d, dims), a)
+		}
+		seen[d] = true
+		ds[i] = d
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] > ds[j] })
+	for _, d := range ds {
+		var err error
+		if a, err = f(a, d, keepDim); err != nil {
+			return Tensor{}, err
+		}
+	}
+	return a, nil
+}
+
+// unbiased returns the option "unbiased", which defaults to true, or the
+// *TorchError of op on a if it isn't a bool.
+func unbiased(op string, a Tensor, opt []map[string]interface{}) (C.int8_t, error) {
+	u, ok := variadic.Lookup(opt, "unbiased")
+	if !ok {
+		return 1, nil
+	}
+	b, ok := u.(bool)
+	if !ok {
+		return 0, newTorchError(op, fmt.Sprintf(
+			"the option unbiased must be a bool, got %T", u), a)
+	}
+	return boolToInt8(b), nil
+}
+
+// Max returns the maximum of all elements, or the maximum values along the
+// option "dim" like torch.max(input, dim, keepdim).values.
+func Max(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryMax(a, opt...))
+}
+
+// TryMax is Max that returns an error rather than panics
+func TryMax(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Max", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	if len(dims) > 0 {
+		return reduceDims("Max", a, dims, keepDim,
+			func(a Tensor, dim int64, keepDim bool) (Tensor, error) {
+				values, _, err := TryMaxByDim(a, dim, keepDim)
+				return values, err
+			})
+	}
+	var t C.Tensor
+	return newTensorOrError("Max", C.Max(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Max is Tensor.max
+func (a Tensor) Max(opt ...map[string]interface{}) Tensor {
+	return Max(a, opt...)
+}
+
+// MaxByDim returns the maximum values and their indices along dim, like
+// torch.max(input, dim, keepdim).
+func MaxByDim(a Tensor, dim int64, keepDim bool) (Tensor, Tensor) {
+	values, indices, err := TryMaxByDim(a, dim, keepDim)
+	if err != nil {
+		panic(err)
+	}
+	return values, indices
+}
+
+// TryMaxByDim is MaxByDim that returns an error rather than panics
+func TryMaxByDim(a Tensor, dim int64, keepDim bool) (Tensor, Tensor, error) {
+	var values, indices C.Tensor
+	if err := CheckNil("MaxByDim", unsafe.Pointer(C.MaxByDim(C.Tensor(*a.T),
+		C.int64_t(dim), boolToInt8(keepDim), &values, &indices)), a); err != nil {
+		return Tensor{}, Tensor{}, err
+	}
+	v, i := newTensorPair(&values, &indices)
+	return v, i, nil
+}
+
+// Min returns the minimum of all elements, or the minimum values along the
+// option "dim" like torch.min(input, dim, keepdim).values.
+func Min(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryMin(a, opt...))
+}
+
+// TryMin is Min that returns an error rather than panics
+func TryMin(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Min", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	if len(dims) > 0 {
+		return reduceDims("Min", a, dims, keepDim,
+			func(a Tensor, dim int64, keepDim bool) (Tensor, error) {
+				values, _, err := TryMinByDim(a, dim, keepDim)
+				return values, err
+			})
+	}
+	var t C.Tensor
+	return newTensorOrError("Min", C.Min(C.Tensor(*a.T), &t), &t, a)
+}
+
+// Min is Tensor.min
+func (a Tensor) Min(opt ...map[string]interface{}) Tensor {
+	return Min(a, opt...)
+}
+
+// MinByDim returns the minimum values and their indices along dim, like
+// torch.min(input, dim, keepdim).
+func MinByDim(a Tensor, dim int64, keepDim bool) (Tensor, Tensor) {
+	values, indices, err := TryMinByDim(a, dim, keepDim)
+	if err != nil {
+		panic(err)
+	}
+	return values, indices
+}
+
+// TryMinByDim is MinByDim that returns an error rather than panics
+func TryMinByDim(a Tensor, dim int64, keepDim bool) (Tensor, Tensor, error) {
+	var values, indices C.Tensor
+	if err := CheckNil("MinByDim", unsafe.Pointer(C.MinByDim(C.Tensor(*a.T),
+		C.int64_t(dim), boolToInt8(keepDim), &values, &indices)), a); err != nil {
+		return Tensor{}, Tensor{}, err
+	}
+	v, i := newTensorPair(&values, &indices)
+	return v, i, nil
+}
+
+// Var is torch.var.  The option "unbiased" defaults to true.
+func Var(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryVar(a, opt...))
+}
+
+// TryVar is Var that returns an error rather than panics
+func TryVar(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Var", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	u, err := unbiased("Var", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	if len(dims) > 0 {
+		return newTensorOrError("Var", C.VarByDims(C.Tensor(*a.T), dimsPtr(dims),
+			C.int64_t(len(dims)), u, boolToInt8(keepDim), &t), &t, a)
+	}
+	return newTensorOrError("Var", C.Var(C.Tensor(*a.T), u, &t), &t, a)
+}
+
+// Var is Tensor.var
+func (a Tensor) Var(opt ...map[string]interface{}) Tensor {
+	return Var(a, opt...)
+}
+
+// Std is torch.std.  The option "unbiased" defaults to true.
+func Std(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryStd(a, opt...))
+}
+
+// TryStd is Std that returns an error rather than panics
+func TryStd(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Std", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	u, err := unbiased("Std", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	if len(dims) > 0 {
+		return newTensorOrError("Std", C.StdByDims(C.Tensor(*a.T), dimsPtr(dims),
+			C.int64_t(len(dims)), u, boolToInt8(keepDim), &t), &t, a)
+	}
+	return newTensorOrError("Std", C.Std(C.Tensor(*a.T), u, &t), &t, a)
+}
+
+// Std is Tensor.std
+func (a Tensor) Std(opt ...map[string]interface{}) Tensor {
+	return Std(a, opt...)
+}
+
+// Prod is torch.prod
+func Prod(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryProd(a, opt...))
+}
+
+// TryProd is Prod that returns an error rather than panics
+func TryProd(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Prod", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	if len(dims) > 0 {
+		return reduceDims("Prod", a, dims, keepDim, prodByDim)
+	}
+	var t C.Tensor
+	return newTensorOrError("Prod", C.Prod(C.Tensor(*a.T), &t), &t, a)
+}
+
+func prodByDim(a Tensor, dim int64, keepDim bool) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Prod", C.ProdByDim(C.Tensor(*a.T), C.int64_t(dim),
+		boolToInt8(keepDim), &t), &t, a)
+}
+
+// Prod is Tensor.prod
+func (a Tensor) Prod(opt ...map[string]interface{}) Tensor {
+	return Prod(a, opt...)
+}
+
+// Norm is torch.norm.  The option "p" could be a number, which defaults to 2,
+// math.Inf(1) for the infinity norm, "fro" for the Frobenius norm, or "nuc"
+// for the nuclear norm.
+func Norm(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryNorm(a, opt...))
+}
+
+// TryNorm is Norm that returns an error rather than panics
+func TryNorm(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Norm", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	var t C.Tensor
+	p, _ := variadic.Lookup(opt, "p")
+	switch p {
+	case "fro":
+		return newTensorOrError("Norm", C.FrobeniusNorm(C.Tensor(*a.T),
+			dimsPtr(dims), C.int64_t(len(dims)), boolToInt8(keepDim), &t), &t, a)
+	case "nuc":
+		return newTensorOrError("Norm", C.NuclearNorm(C.Tensor(*a.T),
+			dimsPtr(dims), C.int64_t(len(dims)), boolToInt8(keepDim), &t), &t, a)
+	}
+	order, err := normOrder(a, p)
+	if err != nil {
+		return Tensor{}, err
+	}
+	return newTensorOrError("Norm", C.Norm(C.Tensor(*a.T), C.double(order),
+		dimsPtr(dims), C.int64_t(len(dims)), boolToInt8(keepDim), &t), &t, a)
+}
+
+// normOrder returns the numeric option "p" of Norm on a.
+func normOrder(a Tensor, p interface{}) (float64, error) {
+	switch p := p.(type) {
+	case nil:
+		return 2, nil
+	case float64:
+		return p, nil
+	case float32:
+		return float64(p), nil
+	case int:
+		return float64(p), nil
+	case int64:
+		return float64(p), nil
+	case int32:
+		return float64(p), nil
+	case string:
+		return 0, newTorchError("Norm", fmt.Sprintf("p=%q is not supported", p), a)
+	}
+	return 0, newTorchError("Norm", fmt.Sprintf(
+		"the option p must be a number, \"fro\", or \"nuc\", got %T", p), a)
+}
+
+// Norm is Tensor.norm
+func (a Tensor) Norm(opt ...map[string]interface{}) Tensor {
+	return Norm(a, opt...)
+}
+
+// LogSumExp is torch.logsumexp.  Without the option "dim", it reduces all
+// dimensions.
+func LogSumExp(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryLogSumExp(a, opt...))
+}
+
+// TryLogSumExp is LogSumExp that returns an error rather than panics
+func TryLogSumExp(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("LogSumExp", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	if len(dims) == 0 {
+		for i := int64(0); i < a.Dim(); i++ {
+			dims = append(dims, i)
+		}
+	}
+	var t C.Tensor
+	return newTensorOrError("LogSumExp", C.LogSumExp(C.Tensor(*a.T),
+		dimsPtr(dims), C.int64_t(len(dims)), boolToInt8(keepDim), &t), &t, a)
+}
+
+// LogSumExp is Tensor.logsumexp
+func (a Tensor) LogSumExp(opt ...map[string]interface{}) Tensor {
+	return LogSumExp(a, opt...)
+}
+
+// Any is torch.any
+func Any(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryAny(a, opt...))
+}
+
+// TryAny is Any that returns an error rather than panics
+func TryAny(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("Any", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	if len(dims) > 0 {
+		return reduceDims("Any", a, dims, keepDim, anyByDim)
+	}
+	var t C.Tensor
+	return newTensorOrError("Any", C.Any(C.Tensor(*a.T), &t), &t, a)
+}
+
+func anyByDim(a Tensor, dim int64, keepDim bool) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Any", C.AnyByDim(C.Tensor(*a.T), C.int64_t(dim),
+		boolToInt8(keepDim), &t), &t, a)
+}
+
+// Any is Tensor.any
+func (a Tensor) Any(opt ...map[string]interface{}) Tensor {
+	return Any(a, opt...)
+}
+
+// All is Tensor.all.  There is no function torch.All because it is the index
+// that selects all elements of a dimension.
+func (a Tensor) All(opt ...map[string]interface{}) Tensor {
+	return Must(a.TryAll(opt...))
+}
+
+// TryAll is All that returns an error rather than panics
+func (a Tensor) TryAll(opt ...map[string]interface{}) (Tensor, error) {
+	dims, keepDim, err := reductionDims("All", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	if len(dims) > 0 {
+		return reduceDims("All", a, dims, keepDim, allByDim)
+	}
+	var t C.Tensor
+	return newTensorOrError("All", C.All(C.Tensor(*a.T), &t), &t, a)
+}
+
+func allByDim(a Tensor, dim int64, keepDim bool) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("All", C.AllByDim(C.Tensor(*a.T), C.int64_t(dim),
+		boolToInt8(keepDim), &t), &t, a)
+}
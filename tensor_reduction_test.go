@@ -0,0 +1,117 @@
+package gotorch_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+type opts = map[string]interface{}
+
+func TestMeanByDims(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2, 3}, {4, 5, 6}})
+	a.Equal([]float32{2.5, 3.5, 4.5}, x.Mean(opts{"dim": 0}).Float32s())
+	a.Equal([][]float32{{2}, {5}}, x.Mean(opts{"dim": 1, "keepDim": true}).ToSlice())
+	a.Equal(float32(3.5), torch.Mean(x, opts{"dim": []int64{0, 1}}).Item())
+	a.Equal([]float32{6, 15}, x.Sum(opts{"dim": int64(1)}).Float32s())
+	a.Equal(float32(21), x.Sum(opts{"dim": []int64{0, 1}}).Item())
+	a.Panics(func() { x.Mean(opts{"dim": "0"}) })
+}
+
+func TestMaxMin(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 7, 3}, {4, 2, 6}})
+	a.Equal(float32(7), x.Max().Item())
+	a.Equal(float32(1), torch.Min(x).Item())
+	a.Equal([]float32{7, 6}, x.Max(opts{"dim": 1}).Float32s())
+
+	values, indices := torch.MaxByDim(x, 0, false)
+	a.Equal([]float32{4, 7, 6}, values.Float32s())
+	a.Equal([]int64{1, 0, 1}, indices.Int64s())
+
+	values, indices = torch.MinByDim(x, 1, true)
+	a.Equal([][]float32{{1}, {2}}, values.ToSlice())
+	a.Equal([][]int64{{0}, {1}}, indices.ToSlice())
+
+	a.Equal(float32(7), x.Max(opts{"dim": []int64{0, 1}}).Item())
+	a.Equal([][]float32{{1}}, x.Min(opts{"dim": []int64{-1, 0}, "keepDim": true}).ToSlice())
+	a.Panics(func() { x.Max(opts{"dim": []int64{1, -1}}) })
+}
+
+func TestVarStd(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2, 3, 4}, {2, 4, 6, 8}})
+	a.InDelta(float32(5.0/3), x.Var(opts{"dim": 1}).Float32s()[0], 1e-6)
+	a.InDelta(float32(1.25), x.Var(opts{"dim": 1, "unbiased": false}).Float32s()[0], 1e-6)
+	a.InDelta(math.Sqrt(1.25), float64(torch.Std(x, opts{"dim": 1, "unbiased": false}).Float32s()[0]), 1e-6)
+	a.Equal([]int64{2, 1}, x.Std(opts{"dim": 1, "keepDim": true}).Shape())
+	a.Equal(0, len(x.Var().Shape()))
+}
+
+func TestProd(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+	a.Equal(float32(24), x.Prod().Item())
+	a.Equal([]float32{3, 8}, torch.Prod(x, opts{"dim": 0}).Float32s())
+	a.Equal(float32(24), torch.Prod(x, opts{"dim": []int64{0, 1}}).Item())
+}
+
+func TestNorm(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{3, 4}, {-6, 8}})
+	a.InDelta(float32(math.Sqrt(125)), x.Norm().Item(), 1e-5)
+	a.Equal([]float32{5, 10}, x.Norm(opts{"dim": 1}).Float32s())
+	a.Equal([]float32{7, 14}, x.Norm(opts{"p": 1, "dim": 1}).Float32s())
+	a.Equal([]float32{4, 8}, x.Norm(opts{"p": math.Inf(1), "dim": 1}).Float32s())
+	a.InDelta(float32(math.Sqrt(125)), torch.Norm(x, opts{"p": "fro"}).Item(), 1e-5)
+	a.Equal(0, len(x.Norm(opts{"p": "nuc"}).Shape()))
+	a.Panics(func() { x.Norm(opts{"p": "bad"}) })
+	a.Panics(func() { x.Norm(opts{"p": true}) })
+}
+
+func TestLogSumExp(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{0, 0}, {1, 1}})
+	a.InDelta(float32(math.Log(2+2*math.E)), x.LogSumExp().Item(), 1e-5)
+	r := torch.LogSumExp(x, opts{"dim": 1}).Float32s()
+	a.InDelta(float32(math.Log(2)), r[0], 1e-5)
+	a.InDelta(float32(1+math.Log(2)), r[1], 1e-5)
+}
+
+func TestAnyAll(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]bool{{true, false}, {true, true}})
+	a.Equal(true, x.Any().Item())
+	a.Equal(false, x.All().Item())
+	a.Equal([]bool{true, false}, x.All(opts{"dim": 0}).Bools())
+	a.Equal([][]bool{{true}, {true}}, torch.Any(x, opts{"dim": 1, "keepDim": true}).ToSlice())
+	a.Equal(false, x.All(opts{"dim": []int64{1, 0}}).Item())
+	a.Equal([][]bool{{true}}, x.Any(opts{"dim": []int64{0, 1}, "keepDim": true}).ToSlice())
+}
+
+func TestTryReductions(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+	_, err := torch.TryMean(x, opts{"dim": "0"})
+	a.Equal("Mean", err.(*torch.TorchError).Op)
+	a.Equal([][]int64{{2, 2}}, err.(*torch.TorchError).Shapes)
+	_, err = torch.TryMax(x, opts{"dim": []int64{1, -1}})
+	a.Equal("Max", err.(*torch.TorchError).Op)
+	_, err = torch.TryProd(x, opts{"keepDim": 1})
+	a.Equal("Prod", err.(*torch.TorchError).Op)
+	_, err = torch.TryVar(x, opts{"unbiased": "no"})
+	a.Equal("Var", err.(*torch.TorchError).Op)
+	_, err = torch.TryNorm(x, opts{"p": "bad"})
+	a.Equal("Norm", err.(*torch.TorchError).Op)
+	_, _, err = torch.TryMinByDim(x, 2, false)
+	a.Equal("MinByDim", err.(*torch.TorchError).Op)
+	_, err = x.TryAll(opts{"dim": 5})
+	a.Error(err)
+
+	r, err := torch.TryStd(x, opts{"dim": 0})
+	a.NoError(err)
+	a.Equal([]int64{2}, r.Shape())
+}
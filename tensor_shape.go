@@ -0,0 +1,264 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// tensorsFromC wraps C tensors returned by C functions like C.Split.
+func tensorsFromC(CT []C.Tensor) []Tensor {
+	r := make([]Tensor, len(CT))
+	for i := range CT {
+		t := CT[i]
+		SetTensorFinalizer((*unsafe.Pointer)(&t))
+		r[i] = Tensor{(*unsafe.Pointer)(&t)}
+	}
+	return r
+}
+
+// mustTensors is Must for functions returning multiple tensors.
+func mustTensors(ts []Tensor, err error) []Tensor {
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+// Cat concatenates tensors along an existing dimension, torch.cat
+func Cat(tensors []Tensor, dim int64) Tensor {
+	return Must(TryCat(tensors, dim))
+}
+
+// TryCat is Cat that returns an error rather than panics
+func TryCat(tensors []Tensor, dim int64) (Tensor, error) {
+	CT := cTensors(tensors)
+	var t C.Tensor
+	return newTensorOrError("Cat",
+		C.Cat(&CT[0], C.int64_t(len(tensors)), C.int64_t(dim), &t), &t, tensors...)
+}
+
+// sizeOf returns the size of the dimension dim, which could be negative.  It
+// returns 0 if dim is out of range, in which case libtorch reports the error.
+func (a Tensor) sizeOf(dim int64) int64 {
+	shape := a.Shape()
+	if dim < 0 {
+		dim += int64(len(shape))
+	}
+	if dim < 0 || dim >= int64(len(shape)) {
+		return 0
+	}
+	return shape[dim]
+}
+
+// Split splits the tensor into chunks of splitSize along dim, torch.split
+func Split(a Tensor, splitSize, dim int64) []Tensor {
+	return mustTensors(TrySplit(a, splitSize, dim))
+}
+
+// TrySplit is Split that returns an error rather than panics
+func TrySplit(a Tensor, splitSize, dim int64) ([]Tensor, error) {
+	n := int64(1)
+	if size := a.sizeOf(dim); splitSize > 0 && size > splitSize {
+		n = (size + splitSize - 1) / splitSize
+	}
+	CT := make([]C.Tensor, n)
+	var l int64
+	if err := CheckNil("Split", unsafe.Pointer(C.Split(C.Tensor(*a.T),
+		C.int64_t(splitSize), C.int64_t(dim), &CT[0], C.int64_t(n),
+		(*C.int64_t)(&l))), a); err != nil {
+		return nil, err
+	}
+	return tensorsFromC(CT[:l]), nil
+}
+
+// Split torch.split
+func (a Tensor) Split(splitSize, dim int64) []Tensor {
+	return Split(a, splitSize, dim)
+}
+
+// SplitWithSizes splits the tensor into chunks of the given sizes along dim,
+// torch.split with a list of sizes
+func SplitWithSizes(a Tensor, sizes []int64, dim int64) []Tensor {
+	return mustTensors(TrySplitWithSizes(a, sizes, dim))
+}
+
+// TrySplitWithSizes is SplitWithSizes that returns an error rather than panics
+func TrySplitWithSizes(a Tensor, sizes []int64, dim int64) ([]Tensor, error) {
+	// The extra element keeps &CT[0] valid if sizes is empty.
+	CT := make([]C.Tensor, len(sizes)+1)
+	if err := CheckNil("SplitWithSizes", unsafe.Pointer(C.SplitWithSizes(
+		C.Tensor(*a.T), dimsPtr(sizes), C.int64_t(len(sizes)),
+		C.int64_t(dim), &CT[0])), a); err != nil {
+		return nil, err
+	}
+	return tensorsFromC(CT[:len(sizes)]), nil
+}
+
+// SplitWithSizes torch.split with a list of sizes
+func (a Tensor) SplitWithSizes(sizes []int64, dim int64) []Tensor {
+	return SplitWithSizes(a, sizes, dim)
+}
+
+// Chunk splits the tensor into at most chunks tensors along dim, torch.chunk
+func Chunk(a Tensor, chunks, dim int64) []Tensor {
+	return mustTensors(TryChunk(a, chunks, dim))
+}
+
+// TryChunk is Chunk that returns an error rather than panics
+func TryChunk(a Tensor, chunks, dim int64) ([]Tensor, error) {
+	n := chunks
+	if n < 1 {
+		n = 1 // libtorch reports the error.
+	}
+	CT := make([]C.Tensor, n)
+	var l int64
+	if err := CheckNil("Chunk", unsafe.Pointer(C.Chunk(C.Tensor(*a.T),
+		C.int64_t(chunks), C.int64_t(dim), &CT[0], (*C.int64_t)(&l))),
+		a); err != nil {
+		return nil, err
+	}
+	return tensorsFromC(CT[:l]), nil
+}
+
+// Chunk torch.chunk
+func (a Tensor) Chunk(chunks, dim int64) []Tensor {
+	return Chunk(a, chunks, dim)
+}
+
+// Unsqueeze torch.unsqueeze
+func Unsqueeze(a Tensor, dim int64) Tensor {
+	return Must(TryUnsqueeze(a, dim))
+}
+
+// TryUnsqueeze is Unsqueeze that returns an error rather than panics
+func TryUnsqueeze(a Tensor, dim int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Unsqueeze",
+		C.Unsqueeze(C.Tensor(*a.T), C.int64_t(dim), &t), &t, a)
+}
+
+// Unsqueeze torch.unsqueeze
+func (a Tensor) Unsqueeze(dim int64) Tensor {
+	return Unsqueeze(a, dim)
+}
+
+// Reshape torch.reshape.  Unlike View, it copies the data if the tensor is not
+// contiguous.
+func Reshape(a Tensor, shape ...int64) Tensor {
+	return Must(TryReshape(a, shape...))
+}
+
+// TryReshape is Reshape that returns an error rather than panics
+func TryReshape(a Tensor, shape ...int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Reshape", C.Reshape(C.Tensor(*a.T),
+		dimsPtr(shape), C.int64_t(len(shape)), &t), &t, a)
+}
+
+// Reshape torch.reshape
+func (a Tensor) Reshape(shape ...int64) Tensor {
+	return Reshape(a, shape...)
+}
+
+// Expand torch.Tensor.expand.  -1 means not changing the size of that
+// dimension.
+func Expand(a Tensor, shape ...int64) Tensor {
+	return Must(TryExpand(a, shape...))
+}
+
+// TryExpand is Expand that returns an error rather than panics
+func TryExpand(a Tensor, shape ...int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Expand", C.Expand(C.Tensor(*a.T),
+		dimsPtr(shape), C.int64_t(len(shape)), &t), &t, a)
+}
+
+// Expand torch.Tensor.expand
+func (a Tensor) Expand(shape ...int64) Tensor {
+	return Expand(a, shape...)
+}
+
+// Repeat torch.Tensor.repeat
+func Repeat(a Tensor, repeats ...int64) Tensor {
+	return Must(TryRepeat(a, repeats...))
+}
+
+// TryRepeat is Repeat that returns an error rather than panics
+func TryRepeat(a Tensor, repeats ...int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Repeat", C.Repeat(C.Tensor(*a.T),
+		dimsPtr(repeats), C.int64_t(len(repeats)), &t), &t, a)
+}
+
+// Repeat torch.Tensor.repeat
+func (a Tensor) Repeat(repeats ...int64) Tensor {
+	return Repeat(a, repeats...)
+}
+
+// Flip torch.flip
+func Flip(a Tensor, dims ...int64) Tensor {
+	return Must(TryFlip(a, dims...))
+}
+
+// TryFlip is Flip that returns an error rather than panics
+func TryFlip(a Tensor, dims ...int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Flip", C.Flip(C.Tensor(*a.T),
+		dimsPtr(dims), C.int64_t(len(dims)), &t), &t, a)
+}
+
+// Flip torch.flip
+func (a Tensor) Flip(dims ...int64) Tensor {
+	return Flip(a, dims...)
+}
+
+// Roll torch.roll.  If dims is empty, the tensor is flattened before rolling
+// and then restored to the original shape.
+func Roll(a Tensor, shifts, dims []int64) Tensor {
+	return Must(TryRoll(a, shifts, dims))
+}
+
+// TryRoll is Roll that returns an error rather than panics
+func TryRoll(a Tensor, shifts, dims []int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Roll", C.Roll(C.Tensor(*a.T),
+		dimsPtr(shifts), C.int64_t(len(shifts)),
+		dimsPtr(dims), C.int64_t(len(dims)), &t), &t, a)
+}
+
+// Roll torch.roll
+func (a Tensor) Roll(shifts, dims []int64) Tensor {
+	return Roll(a, shifts, dims)
+}
+
+// Contiguous returns a contiguous tensor with the same data, or the tensor
+// itself if it is already contiguous, torch.Tensor.contiguous
+func (a Tensor) Contiguous() Tensor {
+	var t C.Tensor
+	MustNil(unsafe.Pointer(C.Contiguous(C.Tensor(*a.T), &t)))
+	SetTensorFinalizer((*unsafe.Pointer)(&t))
+	return Tensor{(*unsafe.Pointer)(&t)}
+}
+
+// IsContiguous returns true if the tensor is contiguous in memory in the
+// row-major order
+func (a Tensor) IsContiguous() bool {
+	var r int8
+	MustNil(unsafe.Pointer(C.Tensor_IsContiguous(C.Tensor(*a.T), (*C.int8_t)(&r))))
+	return r != 0
+}
+
+// Strides returns the strides of each dimension in number of elements
+func (a Tensor) Strides() []int64 {
+	strides := make([]int64, a.Dim())
+	if len(strides) == 0 {
+		return strides
+	}
+	MustNil(unsafe.Pointer(C.Tensor_Strides(C.Tensor(*a.T), dimsPtr(strides))))
+	return strides
+}
@@ -0,0 +1,104 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestCat(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+	y := torch.NewTensor([][]float32{{5, 6}})
+	a.Equal([][]float32{{1, 2}, {3, 4}, {5, 6}}, torch.Cat([]torch.Tensor{x, y}, 0).ToSlice())
+	a.Equal([][]float32{{1, 2, 1, 2}, {3, 4, 3, 4}}, torch.Cat([]torch.Tensor{x, x}, 1).ToSlice())
+	a.Panics(func() { torch.Cat([]torch.Tensor{x, y}, 1) })
+}
+
+func TestSplitChunk(t *testing.T) {
+	a := assert.New(t)
+	x := torch.Arange(0, 10, 1, false)
+
+	s := x.Split(4, 0)
+	a.Equal(3, len(s))
+	a.Equal([]float32{0, 1, 2, 3}, s[0].Float32s())
+	a.Equal([]float32{8, 9}, s[2].Float32s())
+	a.Equal(1, len(torch.Split(x, 20, 0)))
+
+	s = torch.SplitWithSizes(x, []int64{3, 7}, -1)
+	a.Equal(2, len(s))
+	a.Equal([]float32{0, 1, 2}, s[0].Float32s())
+	a.Panics(func() { x.SplitWithSizes([]int64{3, 3}, 0) })
+
+	c := torch.Chunk(x, 3, 0)
+	a.Equal(3, len(c))
+	a.Equal([]int64{4}, c[0].Shape())
+	a.Equal([]int64{2}, c[2].Shape())
+	// torch.chunk may return fewer chunks than requested.
+	a.Equal(2, len(torch.Arange(0, 2, 1, false).Chunk(3, 0)))
+}
+
+func TestUnsqueezeReshapeExpandRepeat(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float32{1, 2, 3})
+	a.Equal([]int64{1, 3}, x.Unsqueeze(0).Shape())
+	a.Equal([]int64{3, 1}, torch.Unsqueeze(x, -1).Shape())
+
+	y := torch.Arange(0, 6, 1, false).View(2, 3).Transpose(0, 1)
+	a.Panics(func() { y.View(6) })
+	a.Equal([]float32{0, 3, 1, 4, 2, 5}, y.Reshape(6).Float32s())
+	a.Equal([]int64{3, 2}, torch.Reshape(y, -1, 2).Shape())
+
+	z := x.Unsqueeze(0).Expand(2, -1)
+	a.Equal([][]float32{{1, 2, 3}, {1, 2, 3}}, z.ToSlice())
+	a.Equal([]int64{0, 1}, z.Strides())
+
+	a.Equal([][]float32{{1, 2, 3, 1, 2, 3}, {1, 2, 3, 1, 2, 3}}, x.Repeat(2, 2).ToSlice())
+}
+
+func TestFlipRoll(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+	a.Equal([][]float32{{4, 3}, {2, 1}}, x.Flip(0, 1).ToSlice())
+	a.Equal([][]float32{{2, 1}, {4, 3}}, torch.Flip(x, 1).ToSlice())
+	a.Equal([][]float32{{3, 4}, {1, 2}}, x.Roll([]int64{1}, []int64{0}).ToSlice())
+	a.Equal([][]float32{{4, 1}, {2, 3}}, torch.Roll(x, []int64{1}, nil).ToSlice())
+}
+
+func TestTryShape(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+
+	_, err := torch.TryCat(nil, 0)
+	a.Error(err)
+	_, err = torch.TrySplitWithSizes(x, nil, 0)
+	a.Error(err)
+	_, err = torch.TrySplit(x, 1, 2)
+	a.Error(err)
+	a.Equal("Split", err.(*torch.TorchError).Op)
+
+	// Flipping no dimensions returns a copy.
+	y, err := torch.TryFlip(x)
+	a.NoError(err)
+	a.Equal([][]float32{{1, 2}, {3, 4}}, y.ToSlice())
+	_, err = torch.TryRoll(x, nil, nil)
+	a.Error(err)
+}
+
+func TestContiguousStrides(t *testing.T) {
+	a := assert.New(t)
+	x := torch.RandN([]int64{2, 3}, false)
+	a.True(x.IsContiguous())
+	a.Equal([]int64{3, 1}, x.Strides())
+
+	y := x.Transpose(0, 1)
+	a.False(y.IsContiguous())
+	a.Equal([]int64{1, 3}, y.Strides())
+	z := y.Contiguous()
+	a.True(z.IsContiguous())
+	a.Equal([]int64{2, 1}, z.Strides())
+	a.True(torch.Equal(y, z))
+
+	a.Equal([]int64{}, torch.NewTensor([]float32{1}).Sum().Strides())
+}
@@ -0,0 +1,258 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include <stdlib.h>
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+func newTensorPair(values, indices *C.Tensor) (Tensor, Tensor) {
+	SetTensorFinalizer((*unsafe.Pointer)(values))
+	SetTensorFinalizer((*unsafe.Pointer)(indices))
+	return Tensor{(*unsafe.Pointer)(values)}, Tensor{(*unsafe.Pointer)(indices)}
+}
+
+// Sort torch.sort returns the sorted values and their indices along dim.  If
+// stable, the order of equivalent elements is preserved, at the cost of
+// sorting on CPU.
+func Sort(a Tensor, dim int64, descending, stable bool) (Tensor, Tensor) {
+	var values, indices C.Tensor
+	MustNil(unsafe.Pointer(C.Sort(C.Tensor(*a.T), C.int64_t(dim),
+		boolToInt8(descending), boolToInt8(stable), &values, &indices)))
+	return newTensorPair(&values, &indices)
+}
+
+// Sort torch.Tensor.sort
+func (a Tensor) Sort(dim int64, descending, stable bool) (Tensor, Tensor) {
+	return Sort(a, dim, descending, stable)
+}
+
+// Argsort torch.argsort returns the indices that sort a along dim
+func Argsort(a Tensor, dim int64, descending, stable bool) Tensor {
+	var t C.Tensor
+	MustNil(unsafe.Pointer(C.Argsort(C.Tensor(*a.T), C.int64_t(dim),
+		boolToInt8(descending), boolToInt8(stable), &t)))
+	SetTensorFinalizer((*unsafe.Pointer)(&t))
+	return Tensor{(*unsafe.Pointer)(&t)}
+}
+
+// Argsort torch.Tensor.argsort
+func (a Tensor) Argsort(dim int64, descending, stable bool) Tensor {
+	return Argsort(a, dim, descending, stable)
+}
+
+// Unique torch.unique returns the unique elements of a, the indices of the
+// elements of a in the unique elements, and the count of each unique element,
+// like torch.unique(a, sorted, return_inverse=True, return_counts=True).
+func Unique(a Tensor, sorted bool) (values, inverse, counts Tensor) {
+	var v, i, c C.Tensor
+	MustNil(unsafe.Pointer(C.Unique(C.Tensor(*a.T), boolToInt8(sorted),
+		&v, &i, &c)))
+	SetTensorFinalizer((*unsafe.Pointer)(&v))
+	SetTensorFinalizer((*unsafe.Pointer)(&i))
+	SetTensorFinalizer((*unsafe.Pointer)(&c))
+	return Tensor{(*unsafe.Pointer)(&v)}, Tensor{(*unsafe.Pointer)(&i)},
+		Tensor{(*unsafe.Pointer)(&c)}
+}
+
+// Unique torch.Tensor.unique
+func (a Tensor) Unique(sorted bool) (values, inverse, counts Tensor) {
+	return Unique(a, sorted)
+}
+
+// Gather torch.gather
+func Gather(a Tensor, dim int64, index Tensor) Tensor {
+	return Must(TryGather(a, dim, index))
+}
+
+// TryGather is Gather that returns an error rather than panics
+func TryGather(a Tensor, dim int64, index Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Gather",
+		C.Gather(C.Tensor(*a.T), C.int64_t(dim), C.Tensor(*index.T), &t),
+		&t, a, index)
+}
+
+// Gather torch.Tensor.gather
+func (a Tensor) Gather(dim int64, index Tensor) Tensor {
+	return Gather(a, dim, index)
+}
+
+// Scatter torch.scatter returns a copy of a with elements of src written at
+// index along dim
+func Scatter(a Tensor, dim int64, index, src Tensor) Tensor {
+	return Must(TryScatter(a, dim, index, src))
+}
+
+// TryScatter is Scatter that returns an error rather than panics
+func TryScatter(a Tensor, dim int64, index, src Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Scatter",
+		C.Scatter(C.Tensor(*a.T), C.int64_t(dim), C.Tensor(*index.T),
+			C.Tensor(*src.T), &t), &t, a, index, src)
+}
+
+// Scatter torch.Tensor.scatter
+func (a Tensor) Scatter(dim int64, index, src Tensor) Tensor {
+	return Scatter(a, dim, index, src)
+}
+
+// ScatterValue torch.scatter with a scalar value
+func ScatterValue(a Tensor, dim int64, index Tensor, value float64) Tensor {
+	return Must(TryScatterValue(a, dim, index, value))
+}
+
+// TryScatterValue is ScatterValue that returns an error rather than panics
+func TryScatterValue(a Tensor, dim int64, index Tensor, value float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ScatterValue",
+		C.ScatterValue(C.Tensor(*a.T), C.int64_t(dim), C.Tensor(*index.T),
+			C.double(value), &t), &t, a, index)
+}
+
+// ScatterValue torch.Tensor.scatter with a scalar value
+func (a Tensor) ScatterValue(dim int64, index Tensor, value float64) Tensor {
+	return ScatterValue(a, dim, index, value)
+}
+
+// ScatterAdd torch.scatter_add returns a copy of a with elements of src added
+// at index along dim
+func ScatterAdd(a Tensor, dim int64, index, src Tensor) Tensor {
+	return Must(TryScatterAdd(a, dim, index, src))
+}
+
+// TryScatterAdd is ScatterAdd that returns an error rather than panics
+func TryScatterAdd(a Tensor, dim int64, index, src Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ScatterAdd",
+		C.ScatterAdd(C.Tensor(*a.T), C.int64_t(dim), C.Tensor(*index.T),
+			C.Tensor(*src.T), &t), &t, a, index, src)
+}
+
+// ScatterAdd torch.Tensor.scatter_add
+func (a Tensor) ScatterAdd(dim int64, index, src Tensor) Tensor {
+	return ScatterAdd(a, dim, index, src)
+}
+
+// ScatterReduce torch.scatter_reduce reduces elements of src into a copy of a
+// at index along dim.  reduce is one of "sum", "prod", "mean", "amax" and
+// "amin", and the reduction includes the elements of a.  Only "sum" and
+// "mean" support autograd; the others run on CPU.
+func ScatterReduce(a Tensor, dim int64, index, src Tensor, reduce string) Tensor {
+	return Must(TryScatterReduce(a, dim, index, src, reduce))
+}
+
+// TryScatterReduce is ScatterReduce that returns an error rather than panics
+func TryScatterReduce(a Tensor, dim int64, index, src Tensor, reduce string) (Tensor, error) {
+	r := C.CString(reduce)
+	defer C.free(unsafe.Pointer(r))
+	var t C.Tensor
+	return newTensorOrError("ScatterReduce",
+		C.ScatterReduce(C.Tensor(*a.T), C.int64_t(dim), C.Tensor(*index.T),
+			C.Tensor(*src.T), r, &t), &t, a, index, src)
+}
+
+// ScatterReduce torch.Tensor.scatter_reduce
+func (a Tensor) ScatterReduce(dim int64, index, src Tensor, reduce string) Tensor {
+	return ScatterReduce(a, dim, index, src, reduce)
+}
+
+// IndexAdd torch.index_add returns a copy of a with slices of src added to
+// the slices at index along dim
+func IndexAdd(a Tensor, dim int64, index, src Tensor) Tensor {
+	return Must(TryIndexAdd(a, dim, index, src))
+}
+
+// TryIndexAdd is IndexAdd that returns an error rather than panics
+func TryIndexAdd(a Tensor, dim int64, index, src Tensor) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("IndexAdd",
+		C.IndexAdd(C.Tensor(*a.T), C.int64_t(dim), C.Tensor(*index.T),
+			C.Tensor(*src.T), &t), &t, a, index, src)
+}
+
+// IndexAdd torch.Tensor.index_add
+func (a Tensor) IndexAdd(dim int64, index, src Tensor) Tensor {
+	return IndexAdd(a, dim, index, src)
+}
+
+// Searchsorted torch.searchsorted returns the indices in the innermost
+// dimension of sortedSequence where inserting values keeps the order.  If
+// right, it returns the last such index rather than the first.
+func Searchsorted(sortedSequence, values Tensor, right bool) Tensor {
+	var t C.Tensor
+	MustNil(unsafe.Pointer(C.Searchsorted(C.Tensor(*sortedSequence.T),
+		C.Tensor(*values.T), boolToInt8(right), &t)))
+	SetTensorFinalizer((*unsafe.Pointer)(&t))
+	return Tensor{(*unsafe.Pointer)(&t)}
+}
+
+// Bucketize torch.bucketize returns the indices of the buckets, defined by
+// the 1-D tensor boundaries, that elements of a fall in
+func Bucketize(a, boundaries Tensor, right bool) Tensor {
+	var t C.Tensor
+	MustNil(unsafe.Pointer(C.Bucketize(C.Tensor(*a.T), C.Tensor(*boundaries.T),
+		boolToInt8(right), &t)))
+	SetTensorFinalizer((*unsafe.Pointer)(&t))
+	return Tensor{(*unsafe.Pointer)(&t)}
+}
+
+// Bucketize torch.bucketize
+func (a Tensor) Bucketize(boundaries Tensor, right bool) Tensor {
+	return Bucketize(a, boundaries, right)
+}
+
+// Kthvalue torch.kthvalue returns the k-th smallest values and their indices
+// along dim
+func Kthvalue(a Tensor, k, dim int64, keepDim bool) (Tensor, Tensor) {
+	var values, indices C.Tensor
+	MustNil(unsafe.Pointer(C.Kthvalue(C.Tensor(*a.T), C.int64_t(k),
+		C.int64_t(dim), boolToInt8(keepDim), &values, &indices)))
+	return newTensorPair(&values, &indices)
+}
+
+// Kthvalue torch.Tensor.kthvalue
+func (a Tensor) Kthvalue(k, dim int64, keepDim bool) (Tensor, Tensor) {
+	return Kthvalue(a, k, dim, keepDim)
+}
+
+// Median torch.median returns the lower median of all elements
+func Median(a Tensor) Tensor {
+	var t C.Tensor
+	MustNil(unsafe.Pointer(C.Median(C.Tensor(*a.T), &t)))
+	SetTensorFinalizer((*unsafe.Pointer)(&t))
+	return Tensor{(*unsafe.Pointer)(&t)}
+}
+
+// Median torch.Tensor.median
+func (a Tensor) Median() Tensor {
+	return Median(a)
+}
+
+// MedianByDim returns the lower medians and their indices along dim, like
+// torch.median(input, dim, keepdim)
+func MedianByDim(a Tensor, dim int64, keepDim bool) (Tensor, Tensor) {
+	var values, indices C.Tensor
+	MustNil(unsafe.Pointer(C.MedianByDim(C.Tensor(*a.T), C.int64_t(dim),
+		boolToInt8(keepDim), &values, &indices)))
+	return newTensorPair(&values, &indices)
+}
+
+// Mode torch.mode returns the most frequent values and their indices along
+// dim
+func Mode(a Tensor, dim int64, keepDim bool) (Tensor, Tensor) {
+	var values, indices C.Tensor
+	MustNil(unsafe.Pointer(C.Mode(C.Tensor(*a.T), C.int64_t(dim),
+		boolToInt8(keepDim), &values, &indices)))
+	return newTensorPair(&values, &indices)
+}
+
+// Mode torch.Tensor.mode
+func (a Tensor) Mode(dim int64, keepDim bool) (Tensor, Tensor) {
+	return Mode(a, dim, keepDim)
+}
@@ -0,0 +1,95 @@
+package gotorch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestSort(t *testing.T) {
+	a := torch.NewTensor([][]float32{{3, 1, 2}, {1, 1, 0}})
+	v, i := a.Sort(1, false, true)
+	assert.Equal(t, []float32{1, 2, 3, 0, 1, 1}, v.Float32s())
+	assert.Equal(t, []int64{1, 2, 0, 2, 0, 1}, i.Int64s())
+
+	v, i = torch.Sort(a, 0, true, true)
+	assert.Equal(t, []float32{3, 1, 2, 1, 1, 0}, v.Float32s())
+	assert.Equal(t, []int64{0, 0, 0, 1, 1, 1}, i.Int64s())
+
+	v, _ = torch.Sort(a, -1, true, false)
+	assert.Equal(t, []float32{3, 2, 1, 1, 1, 0}, v.Float32s())
+
+	assert.Equal(t, []int64{2, 0, 1}, torch.Argsort(a, 1, false, true).Int64s()[3:])
+	assert.Equal(t, []int64{0, 1, 2}, a.Argsort(1, true, true).Int64s()[3:])
+	b := torch.NewTensor([]int64{1 << 60, 1<<60 + 1, 1 << 60})
+	assert.Equal(t, []int64{1, 0, 2}, b.Argsort(0, true, true).Int64s())
+}
+
+func TestUnique(t *testing.T) {
+	a := torch.NewTensor([]int64{2, 1, 2, 3, 1, 2})
+	v, inv, c := a.Unique(true)
+	assert.Equal(t, []int64{1, 2, 3}, v.Int64s())
+	assert.Equal(t, []int64{1, 0, 1, 2, 0, 1}, inv.Int64s())
+	assert.Equal(t, []int64{2, 3, 1}, c.Int64s())
+}
+
+func TestGatherScatter(t *testing.T) {
+	a := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+	index := torch.NewTensor([][]int64{{0, 0}, {1, 0}})
+	assert.Equal(t, []float32{1, 1, 4, 3}, a.Gather(1, index).Float32s())
+	_, e := torch.TryGather(a, 2, index)
+	assert.Error(t, e)
+
+	z := torch.NewTensor([][]float32{{0, 0}, {0, 0}})
+	src := torch.NewTensor([][]float32{{5, 6}, {7, 8}})
+	idx := torch.NewTensor([][]int64{{1, 0}, {0, 1}})
+	assert.Equal(t, []float32{7, 6, 5, 8}, z.Scatter(0, idx, src).Float32s())
+	assert.Equal(t, []float32{9, 0, 0, 9}, z.ScatterValue(1, torch.NewTensor([][]int64{{0}, {1}}), 9).Float32s())
+
+	idx = torch.NewTensor([][]int64{{0, 0}, {0, 1}})
+	assert.Equal(t, []float32{12, 6, 0, 8}, torch.ScatterAdd(z, 0, idx, src).Float32s())
+	assert.Equal(t, []float32{12, 6, 0, 8}, z.ScatterReduce(0, idx, src, "sum").Float32s())
+	assert.Equal(t, []float32{4, 3, 0, 4}, z.ScatterReduce(0, idx, src, "mean").Float32s())
+	assert.Equal(t, []float32{7, 6, 0, 8}, z.ScatterReduce(0, idx, src, "amax").Float32s())
+	assert.Equal(t, []float32{0, 0, 0, 0}, z.ScatterReduce(0, idx, src, "amin").Float32s())
+	o := torch.NewTensor([][]float32{{1, 1}, {1, 1}})
+	assert.Equal(t, []float32{35, 6, 1, 8}, o.ScatterReduce(0, idx, src, "prod").Float32s())
+	_, e = torch.TryScatterReduce(z, 0, idx, src, "median")
+	assert.Error(t, e)
+	_, e = torch.TryScatterReduce(z, 0, torch.NewTensor([]int64{0, 1}), src, "prod")
+	assert.Error(t, e)
+	_, e = torch.TryScatterReduce(z, 0, torch.NewTensor([][]int64{{0, 0, 0}}),
+		torch.NewTensor([][]float32{{1, 2, 3}}), "amax")
+	assert.Error(t, e)
+	zi := torch.NewTensor([][]int64{{1, 1}, {1, 1}})
+	si := torch.NewTensor([][]int64{{3, 5}, {7, 9}})
+	assert.Equal(t, []int64{21, 5, 1, 9}, zi.ScatterReduce(0, idx, si, "prod").Int64s())
+
+	assert.Equal(t, []float32{1, 2, 8, 10},
+		a.IndexAdd(0, torch.NewTensor([]int64{1}), torch.NewTensor([][]float32{{5, 6}})).Float32s())
+}
+
+func TestSearchsortedBucketize(t *testing.T) {
+	s := torch.NewTensor([]float32{1, 3, 5, 7})
+	v := torch.NewTensor([]float32{3, 6, 9})
+	assert.Equal(t, []int64{1, 3, 4}, torch.Searchsorted(s, v, false).Int64s())
+	assert.Equal(t, []int64{2, 3, 4}, torch.Searchsorted(s, v, true).Int64s())
+	assert.Equal(t, []int64{1, 3, 4}, v.Bucketize(s, false).Int64s())
+}
+
+func TestKthvalueMedianMode(t *testing.T) {
+	a := torch.NewTensor([][]float32{{3, 1, 2, 1}, {4, 4, 0, 5}})
+	v, i := a.Kthvalue(2, 1, false)
+	assert.Equal(t, []float32{1, 4}, v.Float32s())
+	assert.Equal(t, []int64{2}, v.Shape())
+	assert.Equal(t, 2, len(i.Int64s()))
+
+	assert.Equal(t, float32(2), a.Median().Item())
+	v, i = torch.MedianByDim(a, 1, true)
+	assert.Equal(t, []float32{1, 4}, v.Float32s())
+	assert.Equal(t, []int64{2, 1}, i.Shape())
+
+	v, _ = a.Mode(1, false)
+	assert.Equal(t, []float32{1, 4}, v.Float32s())
+}
@@ -0,0 +1,121 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// A sparse COO tensor keeps the indices of its non-zero elements in a Long
+// tensor of shape ndim x nnz and the values in a tensor of nnz elements.  An
+// uncoalesced sparse tensor might have duplicate indices, whose values sum
+// up.  MM multiplies a sparse matrix and a dense one into a dense matrix.
+// Data accessors like Float32s return the elements of the dense form, and gob
+// encodes sparse tensors as they are.
+
+// SparseCOO torch.sparse_coo_tensor returns a sparse tensor of the given
+// shape.  If shape is nil, it is the minimum that holds all indices.
+func SparseCOO(indices, values Tensor, shape []int64) Tensor {
+	return Must(TrySparseCOO(indices, values, shape))
+}
+
+// TrySparseCOO is SparseCOO that returns an error rather than panics
+func TrySparseCOO(indices, values Tensor, shape []int64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("SparseCOO", C.SparseCOO(C.Tensor(*indices.T),
+		C.Tensor(*values.T), dimsPtr(shape), C.int64_t(len(shape)), &t),
+		&t, indices, values)
+}
+
+// IsSparse returns true if a is a sparse tensor
+func (a Tensor) IsSparse() bool {
+	var r C.int8_t
+	MustNil(unsafe.Pointer(C.Tensor_IsSparse(C.Tensor(*a.T), &r)))
+	return r != 0
+}
+
+// ToDense returns the dense form of a sparse tensor, or a itself if it is
+// dense
+func (a Tensor) ToDense() Tensor {
+	var t C.Tensor
+	MustNil(unsafe.Pointer(C.Tensor_ToDense(C.Tensor(*a.T), &t)))
+	SetTensorFinalizer((*unsafe.Pointer)(&t))
+	return Tensor{(*unsafe.Pointer)(&t)}
+}
+
+// ToSparse returns the sparse form of a dense tensor.  The optional
+// sparseDims is the number of leading sparse dimensions, which defaults to
+// all dimensions; the other dimensions are dense in values.
+func (a Tensor) ToSparse(sparseDims ...int64) Tensor {
+	d := int64(-1)
+	if len(sparseDims) > 0 {
+		d = sparseDims[0]
+	}
+	var t C.Tensor
+	return Must(newTensorOrError("ToSparse",
+		C.Tensor_ToSparse(C.Tensor(*a.T), C.int64_t(d), &t), &t, a))
+}
+
+// Coalesce returns the sparse tensor with duplicate indices summed up and
+// indices sorted
+func (a Tensor) Coalesce() Tensor {
+	var t C.Tensor
+	return Must(newTensorOrError("Coalesce",
+		C.Tensor_Coalesce(C.Tensor(*a.T), &t), &t, a))
+}
+
+// IsCoalesced returns true if a is a coalesced sparse tensor
+func (a Tensor) IsCoalesced() bool {
+	var r C.int8_t
+	MustNil(unsafe.Pointer(C.Tensor_IsCoalesced(C.Tensor(*a.T), &r)))
+	return r != 0
+}
+
+// Indices returns the indices of a coalesced sparse tensor.  Call Coalesce
+// before Indices for uncoalesced sparse tensors.
+func (a Tensor) Indices() Tensor {
+	var t C.Tensor
+	return Must(newTensorOrError("Indices",
+		C.Tensor_Indices(C.Tensor(*a.T), &t), &t, a))
+}
+
+// Values returns the values of a coalesced sparse tensor
+func (a Tensor) Values() Tensor {
+	var t C.Tensor
+	return Must(newTensorOrError("Values",
+		C.Tensor_Values(C.Tensor(*a.T), &t), &t, a))
+}
+
+// NNZ returns the number of specified elements of a sparse tensor
+func (a Tensor) NNZ() int64 {
+	var r C.int64_t
+	MustNil(unsafe.Pointer(C.Tensor_NNZ(C.Tensor(*a.T), &r)))
+	return int64(r)
+}
+
+// SparseSum torch.sparse.sum sums a sparse tensor over dims.  It returns a
+// dense 0-dim tensor if dims is empty, or a sparse tensor otherwise, unless
+// dims include all sparse dimensions.
+func SparseSum(a Tensor, dims ...int64) Tensor {
+	var t C.Tensor
+	return Must(newTensorOrError("SparseSum", C.SparseSum(C.Tensor(*a.T),
+		dimsPtr(dims), C.int64_t(len(dims)), &t), &t, a))
+}
+
+// Addmm torch.addmm returns beta * input + alpha * (mat1 @ mat2).  mat1 could
+// be sparse, like torch.sparse.addmm.
+func Addmm(input, mat1, mat2 Tensor, beta, alpha float64) Tensor {
+	return Must(TryAddmm(input, mat1, mat2, beta, alpha))
+}
+
+// TryAddmm is Addmm that returns an error rather than panics
+func TryAddmm(input, mat1, mat2 Tensor, beta, alpha float64) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("Addmm", C.Addmm(C.Tensor(*input.T),
+		C.Tensor(*mat1.T), C.Tensor(*mat2.T), C.double(beta), C.double(alpha), &t),
+		&t, input, mat1, mat2)
+}
@@ -0,0 +1,74 @@
+package gotorch_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func TestSparseCOO(t *testing.T) {
+	a := assert.New(t)
+	// Duplicate indices (1, 2) sum up.
+	i := torch.NewTensor([][]int64{{0, 1, 1, 1}, {2, 0, 2, 2}})
+	v := torch.NewTensor([]float32{3, 4, 5, 6})
+	s := torch.SparseCOO(i, v, []int64{2, 3})
+	a.True(s.IsSparse())
+	a.False(s.IsCoalesced())
+	a.Equal([]int64{2, 3}, s.Shape())
+	a.Equal(int64(4), s.NNZ())
+	a.Equal([][]float32{{0, 0, 3}, {4, 0, 11}}, s.ToDense().ToSlice())
+	a.Equal([]float32{0, 0, 3, 4, 0, 11}, s.Float32s())
+
+	c := s.Coalesce()
+	a.True(c.IsCoalesced())
+	a.Equal(int64(3), c.NNZ())
+	a.Equal([]int64{0, 1, 1, 2, 0, 2}, c.Indices().Int64s())
+	a.Equal([]float32{3, 4, 11}, c.Values().Float32s())
+
+	d := torch.NewTensor([][]float32{{0, 1}, {2, 0}})
+	sd := d.ToSparse()
+	a.True(sd.IsSparse())
+	a.Equal(int64(2), sd.NNZ())
+	a.False(d.IsSparse())
+	a.Equal([]float32{0, 1, 2, 0}, sd.ToDense().Float32s())
+
+	inferred := torch.SparseCOO(i, v, nil)
+	a.Equal([]int64{2, 3}, inferred.Shape())
+
+	_, e := torch.TrySparseCOO(i, torch.NewTensor([]float32{1}), nil)
+	a.Error(e)
+}
+
+func TestSparseDenseOperations(t *testing.T) {
+	a := assert.New(t)
+	s := torch.SparseCOO(torch.NewTensor([][]int64{{0, 1}, {1, 0}}),
+		torch.NewTensor([]float32{2, 3}), []int64{2, 2})
+	d := torch.NewTensor([][]float32{{1, 2}, {3, 4}})
+
+	p := torch.MM(s, d)
+	a.False(p.IsSparse())
+	a.Equal([]float32{6, 8, 3, 6}, p.Float32s())
+
+	ones := torch.NewTensor([][]float32{{1, 1}, {1, 1}})
+	a.Equal([]float32{13, 17, 7, 13},
+		torch.Addmm(ones, s, d, 1, 2).Float32s())
+
+	a.Equal(float32(5), torch.SparseSum(s).Item())
+	a.Equal([]float32{3, 2}, torch.SparseSum(s, 0).ToDense().Float32s())
+}
+
+func TestSparseGob(t *testing.T) {
+	a := assert.New(t)
+	s := torch.SparseCOO(torch.NewTensor([][]int64{{0, 2}}),
+		torch.NewTensor([]float64{1.5, -1}), []int64{4})
+	var buf bytes.Buffer
+	a.NoError(gob.NewEncoder(&buf).Encode(s))
+	var r torch.Tensor
+	a.NoError(gob.NewDecoder(&buf).Decode(&r))
+	a.True(r.IsSparse())
+	a.Equal([]int64{4}, r.Shape())
+	a.Equal([]float64{1.5, 0, -1, 0}, r.Float64s())
+}
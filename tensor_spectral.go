@@ -0,0 +1,328 @@
+package gotorch
+
+// #cgo CFLAGS: -I ${SRCDIR}
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch -Wl,-rpath ${SRCDIR}/cgotorch -lcgotorch
+// #cgo LDFLAGS: -L ${SRCDIR}/cgotorch/libtorch/lib -Wl,-rpath ${SRCDIR}/cgotorch/libtorch/lib -lc10 -ltorch -ltorch_cpu
+// #include <stdlib.h>
+// #include "cgotorch/cgotorch.h"
+import "C"
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"unsafe"
+
+	"github.com/wangkuiyi/gotorch/variadic"
+)
+
+// The FFT functions mirror the torch.fft module.  They accept real or complex
+// tensors and return complex tensors, except that IRFFT returns real ones.
+// Options of one-dimensional transforms are "n", the number of points, which
+// defaults to the size of the transformed dimension, "dim", which defaults to
+// -1, and "norm", which is "backward" by default, "ortho", or "forward".
+// Multi-dimensional transforms accept "s", the numbers of points, and "dim",
+// both []int64, instead of "n" and "dim".  For example,
+//
+//	y := torch.FFT(x, map[string]interface{}{"n": 512, "norm": "ortho"})
+
+// FFT torch.fft.fft
+func FFT(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryFFT(a, opt...))
+}
+
+// TryFFT is FFT that returns an error rather than panics
+func TryFFT(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	n, dim, err := fftPoints("FFT", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	return fftn("FFT", a, []int64{n}, []int64{dim}, opt, false)
+}
+
+// IFFT torch.fft.ifft
+func IFFT(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryIFFT(a, opt...))
+}
+
+// TryIFFT is IFFT that returns an error rather than panics
+func TryIFFT(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	n, dim, err := fftPoints("IFFT", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	return fftn("IFFT", a, []int64{n}, []int64{dim}, opt, true)
+}
+
+// FFT2 torch.fft.fft2 transforms the last two dimensions by default
+func FFT2(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryFFT2(a, opt...))
+}
+
+// TryFFT2 is FFT2 that returns an error rather than panics
+func TryFFT2(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	s, dims, err := fftShape("FFT2", a, opt, []int64{-2, -1})
+	if err != nil {
+		return Tensor{}, err
+	}
+	return fftn("FFT2", a, s, dims, opt, false)
+}
+
+// IFFT2 torch.fft.ifft2
+func IFFT2(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryIFFT2(a, opt...))
+}
+
+// TryIFFT2 is IFFT2 that returns an error rather than panics
+func TryIFFT2(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	s, dims, err := fftShape("IFFT2", a, opt, []int64{-2, -1})
+	if err != nil {
+		return Tensor{}, err
+	}
+	return fftn("IFFT2", a, s, dims, opt, true)
+}
+
+// FFTN torch.fft.fftn transforms all dimensions, or the last len(s) ones if
+// the option "s" is specified, by default
+func FFTN(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryFFTN(a, opt...))
+}
+
+// TryFFTN is FFTN that returns an error rather than panics
+func TryFFTN(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	s, dims, err := fftShape("FFTN", a, opt, nil)
+	if err != nil {
+		return Tensor{}, err
+	}
+	return fftn("FFTN", a, s, dims, opt, false)
+}
+
+// IFFTN torch.fft.ifftn
+func IFFTN(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryIFFTN(a, opt...))
+}
+
+// TryIFFTN is IFFTN that returns an error rather than panics
+func TryIFFTN(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	s, dims, err := fftShape("IFFTN", a, opt, nil)
+	if err != nil {
+		return Tensor{}, err
+	}
+	return fftn("IFFTN", a, s, dims, opt, true)
+}
+
+// RFFT torch.fft.rfft transforms a real tensor and returns the n/2+1
+// non-negative frequency terms
+func RFFT(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryRFFT(a, opt...))
+}
+
+// TryRFFT is RFFT that returns an error rather than panics
+func TryRFFT(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	n, dim, err := fftPoints("RFFT", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	norm := C.CString(fftNorm(opt))
+	defer C.free(unsafe.Pointer(norm))
+	var t C.Tensor
+	return newTensorOrError("RFFT", C.RFFT(C.Tensor(*a.T), C.int64_t(n),
+		C.int64_t(dim), norm, &t), &t, a)
+}
+
+// IRFFT torch.fft.irfft is the inverse of RFFT.  The option "n", the length
+// of the real output, defaults to 2*(m-1) for m input frequency terms.
+func IRFFT(a Tensor, opt ...map[string]interface{}) Tensor {
+	return Must(TryIRFFT(a, opt...))
+}
+
+// TryIRFFT is IRFFT that returns an error rather than panics
+func TryIRFFT(a Tensor, opt ...map[string]interface{}) (Tensor, error) {
+	n, dim, err := fftPoints("IRFFT", a, opt)
+	if err != nil {
+		return Tensor{}, err
+	}
+	norm := C.CString(fftNorm(opt))
+	defer C.free(unsafe.Pointer(norm))
+	var t C.Tensor
+	return newTensorOrError("IRFFT", C.IRFFT(C.Tensor(*a.T), C.int64_t(n),
+		C.int64_t(dim), norm, &t), &t, a)
+}
+
+// FFTShift torch.fft.fftshift moves the zero-frequency terms to the center of
+// dims, or all dimensions if dims is empty
+func FFTShift(a Tensor, dims ...int64) Tensor {
+	return Must(TryFFTShift(a, dims...))
+}
+
+// TryFFTShift is FFTShift that returns an error rather than panics
+func TryFFTShift(a Tensor, dims ...int64) (Tensor, error) {
+	return fftShift("FFTShift", a, dims, false)
+}
+
+// IFFTShift torch.fft.ifftshift is the inverse of FFTShift
+func IFFTShift(a Tensor, dims ...int64) Tensor {
+	return Must(TryIFFTShift(a, dims...))
+}
+
+// TryIFFTShift is IFFTShift that returns an error rather than panics
+func TryIFFTShift(a Tensor, dims ...int64) (Tensor, error) {
+	return fftShift("IFFTShift", a, dims, true)
+}
+
+// STFT torch.stft returns the complex short-time Fourier transform of a 1-D
+// signal or a batch of them, of the shape (batch,) freq x frames.  The options
+// are "hop_length", which defaults to nFFT/4, "win_length", which defaults to
+// nFFT, "window", a tensor of size win_length like HannWindow returns,
+// "center", which defaults to true and pads the signal by nFFT/2 on both
+// sides, "pad_mode", which is "reflect" by default, "replicate", or
+// "constant", "normalized", which defaults to false, and "onesided", which
+// defaults to true.
+func STFT(a Tensor, nFFT int64, opt ...map[string]interface{}) Tensor {
+	return Must(TrySTFT(a, nFFT, opt...))
+}
+
+// TrySTFT is STFT that returns an error rather than panics
+func TrySTFT(a Tensor, nFFT int64, opt ...map[string]interface{}) (Tensor, error) {
+	padMode := C.CString(stringOption(opt, "pad_mode", "reflect"))
+	defer C.free(unsafe.Pointer(padMode))
+	var t C.Tensor
+	return newTensorOrError("STFT", C.STFT(C.Tensor(*a.T), C.int64_t(nFFT),
+		C.int64_t(int64Option(opt, "hop_length", -1)),
+		C.int64_t(int64Option(opt, "win_length", -1)), windowOption(opt),
+		boolToInt8(boolOption(opt, "center", true)), padMode,
+		boolToInt8(boolOption(opt, "normalized", false)),
+		boolToInt8(boolOption(opt, "onesided", true)), &t), &t, a)
+}
+
+// ISTFT torch.istft is the inverse of STFT.  It accepts the options of STFT
+// except "pad_mode", and "length", the length of the output signal.
+func ISTFT(a Tensor, nFFT int64, opt ...map[string]interface{}) Tensor {
+	return Must(TryISTFT(a, nFFT, opt...))
+}
+
+// TryISTFT is ISTFT that returns an error rather than panics
+func TryISTFT(a Tensor, nFFT int64, opt ...map[string]interface{}) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("ISTFT", C.ISTFT(C.Tensor(*a.T), C.int64_t(nFFT),
+		C.int64_t(int64Option(opt, "hop_length", -1)),
+		C.int64_t(int64Option(opt, "win_length", -1)), windowOption(opt),
+		boolToInt8(boolOption(opt, "center", true)),
+		boolToInt8(boolOption(opt, "normalized", false)),
+		boolToInt8(boolOption(opt, "onesided", true)),
+		C.int64_t(int64Option(opt, "length", -1)), &t), &t, a)
+}
+
+// HannWindow torch.hann_window returns a Float tensor of windowLength
+// elements.  A periodic window is for STFT, and a symmetric one is for filter
+// design.
+func HannWindow(windowLength int64, periodic bool) Tensor {
+	return Must(TryHannWindow(windowLength, periodic))
+}
+
+// TryHannWindow is HannWindow that returns an error rather than panics
+func TryHannWindow(windowLength int64, periodic bool) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("HannWindow", C.HannWindow(C.int64_t(windowLength),
+		boolToInt8(periodic), &t), &t)
+}
+
+// HammingWindow torch.hamming_window
+func HammingWindow(windowLength int64, periodic bool) Tensor {
+	return Must(TryHammingWindow(windowLength, periodic))
+}
+
+// TryHammingWindow is HammingWindow that returns an error rather than panics
+func TryHammingWindow(windowLength int64, periodic bool) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError("HammingWindow", C.HammingWindow(C.int64_t(windowLength),
+		boolToInt8(periodic), &t), &t)
+}
+
+func fftn(op string, a Tensor, s, dims []int64, opt []map[string]interface{},
+	inverse bool) (Tensor, error) {
+	norm := C.CString(fftNorm(opt))
+	defer C.free(unsafe.Pointer(norm))
+	var t C.Tensor
+	return newTensorOrError(op, C.FFTN(C.Tensor(*a.T),
+		dimsPtr(s), C.int64_t(len(s)), dimsPtr(dims), C.int64_t(len(dims)),
+		norm, boolToInt8(inverse), &t), &t, a)
+}
+
+func fftShift(op string, a Tensor, dims []int64, inverse bool) (Tensor, error) {
+	var t C.Tensor
+	return newTensorOrError(op, C.FFTShift(C.Tensor(*a.T), dimsPtr(dims),
+		C.int64_t(len(dims)), boolToInt8(inverse), &t), &t, a)
+}
+
+// fftPoints returns the options "n" and "dim" of one-dimensional transforms.
+func fftPoints(op string, a Tensor, opt []map[string]interface{}) (n, dim int64, err error) {
+	dims, _, err := reductionDims(op, a, opt)
+	if err != nil {
+		return 0, 0, err
+	}
+	switch len(dims) {
+	case 0:
+		dim = -1
+	case 1:
+		dim = dims[0]
+	default:
+		return 0, 0, newTorchError(op, fmt.Sprintf(
+			"one-dimensional FFTs support only one dim, got %v", dims), a)
+	}
+	return int64Option(opt, "n", -1), dim, nil
+}
+
+// fftShape returns the options "s" and "dim" of multi-dimensional transforms.
+func fftShape(op string, a Tensor, opt []map[string]interface{}, dft []int64) (
+	s, dims []int64, err error) {
+	if dims, _, err = reductionDims(op, a, opt); err != nil {
+		return nil, nil, err
+	}
+	if v, ok := variadic.Lookup(opt, "s"); ok {
+		s = v.([]int64)
+	}
+	if dims == nil {
+		dims = dft
+	}
+	return s, dims, nil
+}
+
+func fftNorm(opt []map[string]interface{}) string {
+	return stringOption(opt, "norm", "backward")
+}
+
+func windowOption(opt []map[string]interface{}) C.Tensor {
+	if w, ok := variadic.Lookup(opt, "window"); ok {
+		return C.Tensor(*w.(Tensor).T)
+	}
+	return nil
+}
+
+// int64Option returns the integer option name, or dft if not specified.
+func int64Option(opt []map[string]interface{}, name string, dft int64) int64 {
+	v, ok := variadic.Lookup(opt, name)
+	if !ok {
+		return dft
+	}
+	switch r := reflect.ValueOf(v); r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return r.Int()
+	}
+	log.Panicf("The option %s must be an integer, got %T", name, v)
+	return dft
+}
+
+func boolOption(opt []map[string]interface{}, name string, dft bool) bool {
+	if v, ok := variadic.Lookup(opt, name); ok {
+		return v.(bool)
+	}
+	return dft
+}
+
+func stringOption(opt []map[string]interface{}, name string, dft string) string {
+	if v, ok := variadic.Lookup(opt, name); ok {
+		return v.(string)
+	}
+	return dft
+}
@@ -0,0 +1,108 @@
+package gotorch_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	torch "github.com/wangkuiyi/gotorch"
+)
+
+func complexInDelta(a *assert.Assertions, expected, actual []complex128) {
+	a.Equal(len(expected), len(actual))
+	for i := range expected {
+		a.InDelta(real(expected[i]), real(actual[i]), 1e-9, "element %d", i)
+		a.InDelta(imag(expected[i]), imag(actual[i]), 1e-9, "element %d", i)
+	}
+}
+
+func TestFFT(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]float64{1, 2, 3, 4})
+	y := torch.FFT(x)
+	a.Equal(torch.ComplexDouble, y.Dtype())
+	complexInDelta(a, []complex128{10, -2 + 2i, -2, -2 - 2i}, y.Complex128s())
+	complexInDelta(a, []complex128{1, 2, 3, 4}, torch.IFFT(y).Complex128s())
+
+	o := torch.FFT(x, map[string]interface{}{"norm": "ortho"})
+	complexInDelta(a, []complex128{5, -1 + 1i, -1, -1 - 1i}, o.Complex128s())
+
+	// Zero-padding to n points.
+	p := torch.FFT(x, map[string]interface{}{"n": 8})
+	a.Equal([]int64{8}, p.Shape())
+	complexInDelta(a, []complex128{10}, p.Complex128s()[:1])
+
+	r := torch.RFFT(x)
+	complexInDelta(a, []complex128{10, -2 + 2i, -2}, r.Complex128s())
+	a.InDeltaSlice([]float64{1, 2, 3, 4}, torch.IRFFT(r).Float64s(), 1e-9)
+	a.InDeltaSlice([]float64{1, 2, 3, 4},
+		torch.IRFFT(torch.RFFT(x, map[string]interface{}{"norm": "forward"}),
+			map[string]interface{}{"n": 4, "norm": "forward"}).Float64s(), 1e-9)
+}
+
+func TestFFTN(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float64{{1, 2}, {3, 4}})
+	y := torch.FFT2(x)
+	complexInDelta(a, []complex128{10, -2, -4, 0}, y.Complex128s())
+	complexInDelta(a, y.Complex128s(), torch.FFTN(x).Complex128s())
+	complexInDelta(a, []complex128{1, 2, 3, 4}, torch.IFFTN(y).Complex128s())
+
+	rows := torch.FFTN(x, map[string]interface{}{"dim": []int64{1}})
+	complexInDelta(a, []complex128{3, -1, 7, -1}, rows.Complex128s())
+}
+
+func TestFFTShift(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([]int64{0, 1, 2, -2, -1})
+	a.Equal([]int64{-2, -1, 0, 1, 2}, torch.FFTShift(x).Int64s())
+	a.Equal([]int64{0, 1, 2, -2, -1}, torch.IFFTShift(torch.FFTShift(x)).Int64s())
+
+	c := torch.NewTensor([]complex64{0, 1i, 2, 3i})
+	a.Equal([]complex64{2, 3i, 0, 1i}, torch.FFTShift(c, 0).Complex64s())
+}
+
+func TestTryFFT(t *testing.T) {
+	a := assert.New(t)
+	x := torch.NewTensor([][]float64{{1, 2}, {3, 4}})
+	_, err := torch.TryFFT(x, map[string]interface{}{"dim": 2})
+	a.Error(err)
+	a.Equal("FFT", err.(*torch.TorchError).Op)
+	_, err = torch.TryIRFFT(x, map[string]interface{}{"norm": "bad"})
+	a.Error(err)
+	_, err = torch.TryFFTShift(x, 3)
+	a.Error(err)
+	_, err = torch.TryHannWindow(-1, true)
+	a.Error(err)
+
+	y, err := torch.TryFFT2(x)
+	a.NoError(err)
+	complexInDelta(a, []complex128{10, -2, -4, 0}, y.Complex128s())
+}
+
+func TestSTFT(t *testing.T) {
+	a := assert.New(t)
+	n := 64
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = math.Sin(2 * math.Pi * float64(i) / 8)
+	}
+	x := torch.NewTensor(v)
+	w := torch.HannWindow(16, true).CastTo(torch.Double)
+	opt := map[string]interface{}{"hop_length": 4, "window": w}
+	s := torch.STFT(x, 16, opt)
+	a.Equal(torch.ComplexDouble, s.Dtype())
+	a.Equal([]int64{9, 17}, s.Shape())
+
+	opt["length"] = n
+	a.InDeltaSlice(v, torch.ISTFT(s, 16, opt).Float64s(), 1e-6)
+
+	_, e := torch.TrySTFT(x, 16, map[string]interface{}{"pad_mode": "circular"})
+	a.Error(e)
+}
+
+func TestWindows(t *testing.T) {
+	a := assert.New(t)
+	a.InDeltaSlice([]float32{0, 0.5, 1, 0.5}, torch.HannWindow(4, true).Float32s(), 1e-6)
+	a.InDeltaSlice([]float32{0.08, 1, 0.08}, torch.HammingWindow(3, false).Float32s(), 1e-6)
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sync"
+	"unsafe"
 
 	torch "github.com/wangkuiyi/gotorch"
 	"github.com/wangkuiyi/gotorch/tool/tgz"
@@ -221,13 +222,18 @@ func (p *ImageLoader) collateMiniBatch(inputs []gocv.Mat, labels []int64) miniBa
 	blob := gocv.NewMat()
 	defer func() {
 		// `gocv.Mat`s must be released manually
-		blob.Close()
 		for _, i := range inputs {
 			i.Close()
 		}
 	}()
 	gocv.BlobFromImages(inputs, &blob, 1.0/255.0, image.Pt(w, h), gocv.NewScalar(0, 0, 0, 0), false, false, gocv.MatTypeCV32F)
-	i := p.trans2.Run(blob).(torch.Tensor)
+	// blobToTensor does the work of `ToTensor`, the first transform in
+	// trans2, without copying the blob.
+	after := p.trans2.Transforms
+	if len(after) > 0 {
+		after = after[1:]
+	}
+	i := transforms.Compose(after...).Run(blobToTensor(blob)).(torch.Tensor)
 	l := torch.NewTensor(labels)
 	if p.pinMemory {
 		return miniBatch{i.PinMemory(), l.PinMemory()}
@@ -235,6 +241,21 @@ func (p *ImageLoader) collateMiniBatch(inputs []gocv.Mat, labels []int64) miniBa
 	return miniBatch{i, l}
 }
 
+// blobToTensor returns a tensor over the NCHW blob without copying.  The
+// tensor takes the ownership of blob, which is closed when libtorch frees the
+// tensor.
+func blobToTensor(blob gocv.Mat) torch.Tensor {
+	size := gocv.GetBlobSize(blob)
+	view, err := blob.DataPtrFloat32()
+	if err != nil {
+		blob.Close()
+		panic(err)
+	}
+	return torch.FromBuffer(unsafe.Pointer(&view[0]), torch.Float,
+		[]int64{int64(size.Val1), int64(size.Val2), int64(size.Val3), int64(size.Val4)},
+		func() { blob.Close() })
+}
+
 // Minibatch returns a minibatch with data and label Tensor
 func (p *ImageLoader) Minibatch() (torch.Tensor, torch.Tensor) {
 	return p.miniBatch.data, p.miniBatch.label